@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+const bookmarksUsage = `usage:
+  grip-cli bookmarks list
+  grip-cli bookmarks add <url> [title...]
+  grip-cli bookmarks rm <n|id>
+  grip-cli bookmarks open <n|id>`
+
+// runBookmarks manages the read-later list in the local store, which the TUI shares.
+func runBookmarks(configPath string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(bookmarksUsage)
+	}
+
+	cfg, err := config.Load(configPath, config.Default())
+	if err != nil {
+		return err
+	}
+	st, err := localStore(cfg.Store)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		bookmarks, err := st.Bookmarks()
+		if err != nil {
+			return err
+		}
+		if len(bookmarks) == 0 {
+			fmt.Println("No bookmarks yet.")
+			return nil
+		}
+		for i, b := range bookmarks {
+			fmt.Printf("[%d] %-60s | %s | %s  (%s)\n", i+1, b.Post.Title, b.Post.Source, b.SavedAt.Local().Format(time.DateOnly), b.ID)
+		}
+		return nil
+
+	case "add":
+		if len(args) < 2 {
+			return errors.New(bookmarksUsage)
+		}
+		u, err := url.Parse(args[1])
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%q is not an absolute http(s) URL", args[1])
+		}
+		p := logic.Post{URL: args[1], Title: strings.Join(args[2:], " "), Source: u.Host}
+		if p.Title == "" {
+			p.Title = p.URL
+		}
+		b, created, err := st.AddBookmark(p)
+		if err != nil {
+			return err
+		}
+		if !created {
+			fmt.Printf("Already bookmarked as %s.\n", b.ID)
+			return nil
+		}
+		fmt.Printf("Bookmarked %s as %s.\n", b.Post.Title, b.ID)
+		return nil
+
+	case "rm", "open":
+		if len(args) != 2 {
+			return errors.New(bookmarksUsage)
+		}
+		b, err := findBookmark(st, args[1])
+		if err != nil {
+			return err
+		}
+		if args[0] == "open" {
+			openPost(st, b.Post)
+			return nil
+		}
+		if err := st.RemoveBookmark(b.ID); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %s.\n", b.Post.Title)
+		return nil
+
+	default:
+		return errors.New(bookmarksUsage)
+	}
+}
+
+// localStore opens the per-user store holding bookmarks and reading history.
+func localStore(cfg config.Store) (*store.Store, error) {
+	path, err := cfg.Local()
+	if err != nil {
+		return nil, err
+	}
+	return store.Open(path)
+}
+
+// findBookmark resolves ref as a 1-based position in "bookmarks list" or as a bookmark ID.
+func findBookmark(st *store.Store, ref string) (store.Bookmark, error) {
+	bookmarks, err := st.Bookmarks()
+	if err != nil {
+		return store.Bookmark{}, err
+	}
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(bookmarks) {
+			return store.Bookmark{}, fmt.Errorf("no bookmark [%d]; there are %d", n, len(bookmarks))
+		}
+		return bookmarks[n-1], nil
+	}
+	for _, b := range bookmarks {
+		if b.ID == ref {
+			return b, nil
+		}
+	}
+	return store.Bookmark{}, fmt.Errorf("bookmark %s: %w", ref, store.ErrNotFound)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/digest"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+// runDigest emails each configured recipient the posts their saved searches
+// found since their last digest. It is meant to be run daily from cron.
+func runDigest(configPath string, args []string) error {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "write the rendered emails to stdout instead of sending them")
+	to := fs.String("to", "", "only build the digest for this recipient")
+	window := fs.Duration("since", 24*time.Hour, "look back this far for recipients who have never had a digest")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(configPath, config.Default())
+	if err != nil {
+		return err
+	}
+	if len(cfg.Digest.Recipients) == 0 {
+		return errors.New("no digest.recipients in " + configPath)
+	}
+	st, err := store.Open(cfg.Store.Path)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	found := false
+	for _, r := range cfg.Digest.Recipients {
+		if *to != "" && r.Email != *to {
+			continue
+		}
+		found = true
+
+		since, err := st.LastDigest(r.Email)
+		if err != nil {
+			return err
+		}
+		if since.IsZero() {
+			since = now.Add(-*window)
+		}
+
+		d, err := digest.Build(st, r, cfg.Digest.Subject, since, now)
+		if err != nil {
+			return err
+		}
+		if d.Count() == 0 {
+			fmt.Fprintf(os.Stderr, "%s: nothing new since %s, skipping.\n", r.Email, since.Local().Format(time.DateTime))
+			continue
+		}
+
+		msg, err := digest.Render(d, cfg.Digest.From)
+		if err != nil {
+			return err
+		}
+		if *dryRun {
+			os.Stdout.Write(msg)
+			fmt.Println()
+			continue
+		}
+
+		if err := digest.Send(cfg.Digest.SMTP, cfg.Digest.From, []string{r.Email}, msg); err != nil {
+			return fmt.Errorf("%s: %w", r.Email, err)
+		}
+		if err := st.MarkDigest(r.Email, now); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s: sent %d post(s).\n", r.Email, d.Count())
+	}
+
+	if !found {
+		return fmt.Errorf("no digest recipient %q in %s", *to, configPath)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+const historyUsage = `usage:
+  grip-cli history list [n]     (the last n opened posts, default 20)
+  grip-cli history open <n>
+  grip-cli history clear`
+
+// runHistory shows the posts opened from grip-cli and grip-tui.
+func runHistory(configPath string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(historyUsage)
+	}
+
+	cfg, err := config.Load(configPath, config.Default())
+	if err != nil {
+		return err
+	}
+	st, err := localStore(cfg.Store)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		limit := 20
+		if len(args) > 1 {
+			if limit, err = strconv.Atoi(args[1]); err != nil || limit < 1 {
+				return errors.New(historyUsage)
+			}
+		}
+		visits, err := st.History(limit)
+		if err != nil {
+			return err
+		}
+		if len(visits) == 0 {
+			fmt.Println("Nothing opened yet.")
+			return nil
+		}
+		for i, v := range visits {
+			fmt.Printf("[%d] %-60s | %s | %s  (%dx)\n", i+1, v.Post.Title, v.Post.Source, v.OpenedAt.Local().Format(time.DateTime), v.Opens)
+		}
+		return nil
+
+	case "open":
+		if len(args) != 2 {
+			return errors.New(historyUsage)
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return errors.New(historyUsage)
+		}
+		visits, err := st.History(n)
+		if err != nil {
+			return err
+		}
+		if n > len(visits) {
+			return fmt.Errorf("no history entry [%d]; there are %d", n, len(visits))
+		}
+		openPost(st, visits[n-1].Post)
+		return nil
+
+	case "clear":
+		if len(args) != 1 {
+			return errors.New(historyUsage)
+		}
+		return st.ClearHistory()
+
+	default:
+		return errors.New(historyUsage)
+	}
+}
+
+// openPost opens p in the browser and records it as read. A history write
+// failure is only reported, since the post has already been opened.
+func openPost(st *store.Store, p logic.Post) {
+	openURL(p.URL)
+	if _, err := st.RecordVisit(p, time.Now()); err != nil {
+		fmt.Fprintln(os.Stderr, "could not record the visit:", err)
+	}
+}
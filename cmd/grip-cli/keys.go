@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+const keysUsage = `usage:
+  grip-cli keys list
+  grip-cli keys create [-rpm n] <owner>   (prints the key once; -rpm overrides the per-key limit)
+  grip-cli keys revoke <id>`
+
+// runKeys administers the API keys checked when server.auth is enabled.
+func runKeys(configPath string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(keysUsage)
+	}
+
+	cfg, err := config.Load(configPath, config.Default())
+	if err != nil {
+		return err
+	}
+	st, err := store.Open(cfg.Store.Path)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		keys, err := st.Keys()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-8s  %-20s %-8s %-10s %-19s %s\n", "ID", "OWNER", "RPM", "REQUESTS", "LAST USED", "STATUS")
+		for _, k := range keys {
+			rpm := "default"
+			if k.RequestsPerMinute > 0 {
+				rpm = fmt.Sprint(k.RequestsPerMinute)
+			}
+			lastUsed := "never"
+			if !k.LastUsedAt.IsZero() {
+				lastUsed = k.LastUsedAt.Local().Format(time.DateTime)
+			}
+			status := "active"
+			if k.Revoked() {
+				status = "revoked " + k.RevokedAt.Local().Format(time.DateTime)
+			}
+			fmt.Printf("%-8s  %-20s %-8s %-10d %-19s %s\n", k.ID, k.Owner, rpm, k.Requests, lastUsed, status)
+		}
+		return nil
+
+	case "create":
+		fs := flag.NewFlagSet("keys create", flag.ContinueOnError)
+		rpm := fs.Int("rpm", 0, "requests per minute for this key (0 uses server.auth.key_rate_limit)")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return errors.New(keysUsage)
+		}
+		secret, key, err := st.CreateKey(fs.Arg(0), *rpm)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created key %s for %s. Store it now, it cannot be shown again:\n\n  %s\n\n", key.ID, key.Owner, secret)
+		if !cfg.Server.Auth.Enabled {
+			fmt.Println("Note: server.auth.enabled is false, so the server does not check keys yet.")
+		}
+		return nil
+
+	case "revoke":
+		if len(args) != 2 {
+			return errors.New(keysUsage)
+		}
+		if err := st.RevokeKey(args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Revoked key %s; running servers stop accepting it within 10 seconds.\n", args[1])
+		return nil
+
+	default:
+		return errors.New(keysUsage)
+	}
+}
@@ -2,53 +2,118 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Numpkens/grip/internal/config"
 	"github.com/Numpkens/grip/internal/logic"
 	"github.com/Numpkens/grip/internal/logic/sources"
 )
 
+// commands maps each subcommand to its handler. Anything else is a search.
+var commands = map[string]func(configPath string, args []string) error{
+	"sources":   runSources,
+	"searches":  runSearches,
+	"webhooks":  runWebhooks,
+	"digest":    runDigest,
+	"keys":      runKeys,
+	"bookmarks": runBookmarks,
+	"history":   runHistory,
+}
+
 func main() {
+	configPath := flag.String("config", config.PathFromEnv(), "path to the JSON config file (env GRIP_CONFIG)")
+	hideRead := flag.Bool("hide-read", false, "leave out posts you have already opened")
+	flag.Parse()
+	args := flag.Args()
+
+	// Subcommands take the first argument, so a search for one of their names
+	// needs a "--" first, as in "grip-cli -- history".
+	if len(args) > 0 && !afterTerminator(args) {
+		if run, ok := commands[args[0]]; ok {
+			if err := run(*configPath, args[1:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	query := "golang"
-	if len(os.Args) > 1 {
-		query = os.Args[1]
+	if len(args) > 0 {
+		query = args[0]
+	}
+
+	cfg, err := config.Load(*configPath, config.Default())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
 	client := &http.Client{Timeout: 5 * time.Second}
-	engine := logic.NewEngine([]logic.Source{
-		&sources.DevTo{Client: client},
-		&sources.HackerNews{Client: client},
-		&sources.Hashnode{Client: client},
-		&sources.BootDev{Client: client},
-		&sources.Lobsters{Client: client, BaseURL: "https://lobste.rs"},
-		&sources.FreeCodeCamp{Client: client, BaseURL: "https://www.freecodecamp.org"},
-	})
+	engine := logic.NewEngine(sources.FromConfig(cfg.Sources, client))
 
 	fmt.Printf("Searching for %s...\n", query)
 	posts := engine.Collect(context.Background(), query)
 
+	// The local store only adds read marks and bookmarks, so searching works without it.
+	local, err := localStore(cfg.Store)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "bookmarks and history unavailable:", err)
+	}
+	read := map[string]bool{}
+	if local != nil {
+		read, _ = local.ReadURLs()
+	}
+	if *hideRead {
+		posts = slices.DeleteFunc(posts, func(p logic.Post) bool { return read[p.URL] })
+	}
+
 	if len(posts) == 0 {
 		fmt.Println("No results found.")
 		return
 	}
 
-
 	for i, p := range posts {
-		fmt.Printf("[%d] %-60s | %s\n", i+1, p.Title, p.Source)
+		mark := " "
+		if read[p.URL] {
+			mark = "✓"
+		}
+		fmt.Printf("[%d] %s %-60s | %s\n", i+1, mark, p.Title, p.Source)
 	}
 
+	fmt.Print("\nEnter number to open, s<number> to bookmark (0 to exit): ")
+	var input string
+	fmt.Scanln(&input)
 
-	fmt.Print("\nEnter number to open (0 to exit): ")
-	var choice int
-	fmt.Scanln(&choice)
-
-	if choice > 0 && choice <= len(posts) {
-		openURL(posts[choice-1].URL)
+	save := strings.HasPrefix(input, "s")
+	choice, _ := strconv.Atoi(strings.TrimPrefix(input, "s"))
+	if choice <= 0 || choice > len(posts) {
+		return
+	}
+	if local == nil {
+		if !save {
+			openURL(posts[choice-1].URL)
+		}
+		return
 	}
+	if save {
+		if _, _, err := local.AddBookmark(posts[choice-1]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println("Bookmarked. See them with: grip-cli bookmarks list")
+		return
+	}
+	openPost(local, posts[choice-1])
 }
 
 func openURL(url string) {
@@ -62,9 +127,16 @@ func openURL(url string) {
 	case "darwin":
 		cmd = "open"
 		args = []string{url}
-	default: 
+	default:
 		cmd = "xdg-open"
 		args = []string{url}
 	}
 	exec.Command(cmd, args...).Start()
-}
\ No newline at end of file
+}
+
+// afterTerminator reports whether args, the arguments left by flag.Parse,
+// came after a "--", which flag.Parse consumes.
+func afterTerminator(args []string) bool {
+	i := len(os.Args) - len(args) - 1
+	return i > 0 && os.Args[i] == "--"
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/logic/sources"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+const searchesUsage = `usage:
+  grip-cli searches list
+  grip-cli searches add <name> <query> [source,...]
+  grip-cli searches rm <name>
+  grip-cli searches new <name>          (posts flagged by background polling)`
+
+// runSearches manages the saved searches in the server-side store.
+func runSearches(configPath string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(searchesUsage)
+	}
+
+	cfg, err := config.Load(configPath, config.Default())
+	if err != nil {
+		return err
+	}
+	st, err := store.Open(cfg.Store.Path)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		searches, err := st.Searches()
+		if err != nil {
+			return err
+		}
+		for _, ss := range searches {
+			polled := "never"
+			if !ss.LastPolledAt.IsZero() {
+				polled = ss.LastPolledAt.Local().Format(time.DateTime)
+			}
+			fmt.Printf("%-20s %-30q %-25s polled %s\n", ss.Name, ss.Query, strings.Join(ss.Sources, ","), polled)
+		}
+		return nil
+
+	case "add":
+		if len(args) < 3 || len(args) > 4 {
+			return errors.New(searchesUsage)
+		}
+		ss := store.SavedSearch{Name: args[1], Query: args[2]}
+		if len(args) == 4 {
+			ss.Sources = strings.Split(args[3], ",")
+			// Check the names against the configured sources, as the API does.
+			engine := logic.NewEngine(sources.FromConfig(cfg.Sources, http.DefaultClient))
+			if err := engine.CheckSources(ss.Sources); err != nil {
+				return err
+			}
+		}
+		if _, err := st.AddSearch(ss); err != nil {
+			return err
+		}
+		fmt.Printf("Saved %q; it will be polled by the next grip-web or grip-api run.\n", ss.Name)
+		return nil
+
+	case "rm":
+		if len(args) != 2 {
+			return errors.New(searchesUsage)
+		}
+		return st.DeleteSearch(args[1])
+
+	case "new":
+		if len(args) != 2 {
+			return errors.New(searchesUsage)
+		}
+		matches, err := st.Matches(args[1], time.Time{})
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			fmt.Println("No new posts yet.")
+		}
+		for _, m := range matches {
+			fmt.Printf("%s  %-60s | %s\n  %s\n", m.FoundAt.Local().Format(time.DateTime), m.Post.Title, m.Post.Source, m.Post.URL)
+		}
+		return nil
+
+	default:
+		return errors.New(searchesUsage)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/opml"
+)
+
+const sourcesUsage = `usage:
+  grip-cli sources list
+  grip-cli sources import <file.opml>
+  grip-cli sources export [file.opml]   (writes to stdout without a file)`
+
+// runSources manages the feed sources stored in the config file.
+func runSources(configPath string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(sourcesUsage)
+	}
+
+	cfg, err := config.Read(configPath, config.Default())
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		for _, f := range cfg.Sources.Feeds {
+			fmt.Printf("%-40s %s\n", f.Name, f.URL)
+		}
+		return nil
+
+	case "import":
+		if len(args) != 2 {
+			return errors.New(sourcesUsage)
+		}
+		f, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		doc, err := opml.Parse(f)
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[1], err)
+		}
+
+		var added int
+		cfg.Sources.Feeds, added = opml.Merge(cfg.Sources.Feeds, doc.Feeds())
+		if err := config.Save(configPath, cfg); err != nil {
+			return err
+		}
+		fmt.Printf("Imported %d new feed(s) into %s (%d total).\n", added, configPath, len(cfg.Sources.Feeds))
+		return nil
+
+	case "export":
+		var w io.Writer = os.Stdout
+		if len(args) == 2 {
+			f, err := os.Create(args[1])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		return opml.Write(w, opml.FromFeeds("GRIP sources", cfg.Sources.Feeds))
+
+	default:
+		return errors.New(sourcesUsage)
+	}
+}
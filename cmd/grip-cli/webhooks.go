@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/notify"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+const webhooksUsage = `usage:
+  grip-cli webhooks list
+  grip-cli webhooks log [n]             (last n deliveries, default 20)
+  grip-cli webhooks test <name>         (sends a sample post to one hook)`
+
+// runWebhooks inspects and exercises the webhooks in the config file.
+func runWebhooks(configPath string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(webhooksUsage)
+	}
+
+	cfg, err := config.Load(configPath, config.Default())
+	if err != nil {
+		return err
+	}
+	st, err := store.Open(cfg.Store.Path)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		for _, h := range cfg.Webhooks {
+			format := h.Format
+			if format == "" {
+				format = "json"
+			}
+			signed := "unsigned"
+			if h.Secret != "" {
+				signed = "signed"
+			}
+			fmt.Printf("%-20s %-8s %-9s %s\n", h.Name, format, signed, h.URL)
+		}
+		return nil
+
+	case "log":
+		n := 20
+		if len(args) == 2 {
+			if _, err := fmt.Sscan(args[1], &n); err != nil || n < 1 {
+				return errors.New(webhooksUsage)
+			}
+		}
+		deliveries, err := st.Deliveries(n)
+		if err != nil {
+			return err
+		}
+		for _, d := range deliveries {
+			result := "ok"
+			if !d.OK {
+				result = "FAILED: " + d.Error
+			}
+			fmt.Printf("%s  %s  %-15s %-15s %d post(s), %d attempt(s)  %s\n",
+				d.SentAt.Local().Format(time.DateTime), d.ID, d.Webhook, d.Search, d.Posts, d.Attempts, result)
+		}
+		return nil
+
+	case "test":
+		if len(args) != 2 {
+			return errors.New(webhooksUsage)
+		}
+		n, err := notify.New(cfg.Webhooks, &http.Client{Timeout: 10 * time.Second}, st)
+		if err != nil {
+			return err
+		}
+		for _, h := range n.Hooks {
+			if h.Name != args[1] {
+				continue
+			}
+			sample := []logic.Post{{
+				Title:       "GRIP webhook test",
+				URL:         "https://github.com/Numpkens/grip",
+				Source:      "GRIP",
+				PublishedAt: time.Now().UTC(),
+			}}
+			d := n.Deliver(context.Background(), h, store.SavedSearch{Name: "test", Query: "test"}, sample)
+			if !d.OK {
+				return fmt.Errorf("delivery %s failed after %d attempt(s): %s", d.ID, d.Attempts, d.Error)
+			}
+			fmt.Printf("Delivered %s to %s (HTTP %d).\n", d.ID, h.Name, d.StatusCode)
+			return nil
+		}
+		return fmt.Errorf("no webhook named %q in %s", args[1], configPath)
+
+	default:
+		return errors.New(webhooksUsage)
+	}
+}
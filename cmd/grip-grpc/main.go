@@ -0,0 +1,59 @@
+// Command grip-grpc serves the GRIP search engine over gRPC, with the standard
+// health service and server reflection enabled for tools like grpcurl.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/logic/sources"
+	"github.com/Numpkens/grip/internal/rpc"
+)
+
+func main() {
+	configPath := flag.String("config", config.PathFromEnv(), "path to the JSON config file (env GRIP_CONFIG)")
+	addr := flag.String("addr", "", "listen address, overrides the config (env GRIP_GRPC_ADDR)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath, config.Default())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *addr != "" {
+		cfg.GRPC.Addr = *addr
+	}
+
+	lis, err := net.Listen("tcp", cfg.GRPC.Addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	engine := logic.NewEngine(sources.FromConfig(cfg.Sources, sources.NewHTTPClient()))
+	srv := rpc.NewGRPCServer(engine)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		log.Println("GRIP gRPC shutting down, draining streams...")
+
+		// GracefulStop waits for every RPC; force it if a client hangs on.
+		timer := time.AfterFunc(15*time.Second, srv.Stop)
+		defer timer.Stop()
+		srv.GracefulStop()
+	}()
+
+	log.Printf("GRIP gRPC starting on %s", cfg.GRPC.Addr)
+	if err := srv.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+}
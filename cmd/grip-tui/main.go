@@ -7,6 +7,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -15,8 +16,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Numpkens/grip/internal/config"
 	"github.com/Numpkens/grip/internal/logic"
 	"github.com/Numpkens/grip/internal/logic/sources"
+	"github.com/Numpkens/grip/internal/store"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -39,27 +42,37 @@ const (
 
 // keyMap defines the keyboard shortcuts for the application navigation.
 type keyMap struct {
-	Up     key.Binding
-	Down   key.Binding
-	Search key.Binding
-	Enter  key.Binding
-	Quit   key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Search    key.Binding
+	Enter     key.Binding
+	Saved     key.Binding
+	Save      key.Binding
+	Bookmarks key.Binding
+	History   key.Binding
+	HideRead  key.Binding
+	Quit      key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Search, k.Enter, k.Quit}
+	return []key.Binding{k.Up, k.Down, k.Search, k.Enter, k.Save, k.Bookmarks, k.History, k.HideRead, k.Saved, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Up, k.Down}, {k.Search, k.Enter, k.Quit}}
+	return [][]key.Binding{{k.Up, k.Down}, {k.Search, k.Enter, k.Save, k.Bookmarks, k.History, k.HideRead, k.Saved, k.Quit}}
 }
 
 var keys = keyMap{
-	Up:     key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
-	Down:   key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
-	Search: key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
-	Enter:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open")),
-	Quit:   key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	Up:        key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+	Down:      key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+	Search:    key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+	Enter:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open")),
+	Saved:     key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "saved searches")),
+	Save:      key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "save")),
+	Bookmarks: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "bookmarks")),
+	History:   key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "history")),
+	HideRead:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "hide read")),
+	Quit:      key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
 }
 
 var (
@@ -100,9 +113,45 @@ type resultsMsg struct {
 	latency time.Duration
 }
 
+// savedMsg carries the saved searches loaded from the store.
+type savedMsg struct {
+	searches []store.SavedSearch
+	err      error
+}
+
+// marksMsg carries the bookmarked and read URLs after loading or changing them.
+type marksMsg struct {
+	saved map[string]bool
+	read  map[string]bool
+	err   error
+}
+
+// gridMode selects what the post grid shows.
+type gridMode int
+
+const (
+	modeResults gridMode = iota
+	modeBookmarks
+	modeHistory
+)
+
 type model struct {
 	engine      *logic.Engine
+	store       *store.Store
+	storeErr    error        // why store could not be opened
+	local       *store.Store // holds bookmarks and history, shared with grip-cli
+	localErr    error        // why local could not be opened
 	posts       []logic.Post
+	sources     []string // restricts fetches to a saved search's sources
+	saved       []store.SavedSearch
+	savedErr    error
+	savedCursor int
+	showSaved   bool
+	mode        gridMode
+	bookmarked  map[string]bool
+	read        map[string]bool
+	marksErr    error
+	hideRead    bool
 	latency     time.Duration
 	cursor      int
 	loading     bool
@@ -120,16 +169,114 @@ type model struct {
 func fetchCmd(m model) tea.Cmd {
 	return func() tea.Msg {
 		start := time.Now()
-		res := m.engine.Collect(context.Background(), m.searchInput.Value())
+		res, _ := m.engine.Search(context.Background(), logic.SearchOptions{
+			Query:   m.searchInput.Value(),
+			Sources: m.sources,
+		})
 		return resultsMsg{
-			posts:   res,
+			posts:   res.Posts,
 			latency: time.Since(start),
 		}
 	}
 }
 
+func loadSavedCmd(st *store.Store, openErr error) tea.Cmd {
+	return func() tea.Msg {
+		if st == nil {
+			return savedMsg{err: openErr}
+		}
+		searches, err := st.Searches()
+		return savedMsg{searches: searches, err: err}
+	}
+}
+
+// fetchLocalCmd lists bookmarks or reading history in place of search results.
+func fetchLocalCmd(st *store.Store, mode gridMode) tea.Cmd {
+	return func() tea.Msg {
+		if st == nil {
+			return resultsMsg{}
+		}
+		var posts []logic.Post
+		if mode == modeHistory {
+			visits, _ := st.History(0)
+			for _, v := range visits {
+				posts = append(posts, v.Post)
+			}
+		} else {
+			bookmarks, _ := st.Bookmarks()
+			for _, b := range bookmarks {
+				posts = append(posts, b.Post)
+			}
+		}
+		return resultsMsg{posts: posts}
+	}
+}
+
+// toggleBookmarkCmd saves p, or removes it when it is already bookmarked.
+func toggleBookmarkCmd(st *store.Store, openErr error, p logic.Post, saved bool) tea.Cmd {
+	return func() tea.Msg {
+		if st == nil {
+			return marksMsg{err: openErr}
+		}
+		var err error
+		if saved {
+			err = st.RemoveBookmark(store.BookmarkID(p.URL))
+		} else {
+			_, _, err = st.AddBookmark(p)
+		}
+		if err != nil {
+			return marksMsg{err: err}
+		}
+		return loadMarksCmd(st, nil)()
+	}
+}
+
+// visitCmd records p as read after it was opened.
+func visitCmd(st *store.Store, openErr error, p logic.Post) tea.Cmd {
+	return func() tea.Msg {
+		if st == nil {
+			return marksMsg{err: openErr}
+		}
+		if _, err := st.RecordVisit(p, time.Now()); err != nil {
+			return marksMsg{err: err}
+		}
+		return loadMarksCmd(st, nil)()
+	}
+}
+
+// loadMarksCmd reads which posts are bookmarked or read. openErr is reported
+// when st is nil because the local store could not be opened.
+func loadMarksCmd(st *store.Store, openErr error) tea.Cmd {
+	return func() tea.Msg {
+		if st == nil {
+			return marksMsg{err: openErr}
+		}
+		saved, err := st.BookmarkedURLs()
+		if err != nil {
+			return marksMsg{err: err}
+		}
+		read, err := st.ReadURLs()
+		return marksMsg{saved: saved, read: read, err: err}
+	}
+}
+
 func (m model) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, fetchCmd(m))
+	return tea.Batch(m.spinner.Tick, fetchCmd(m), loadMarksCmd(m.local, m.localErr))
+}
+
+// visible returns the posts shown in the grid, which leaves out read ones
+// when hideRead is on. The history view always shows everything.
+func (m model) visible() []logic.Post {
+	if !m.hideRead || m.mode == modeHistory {
+		return m.posts
+	}
+	var out []logic.Post
+	for _, p := range m.posts {
+		if !m.read[p.URL] {
+			out = append(out, p)
+		}
+	}
+	return out
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -146,6 +293,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searching = false
 				m.loading = true
 				m.cursor = 0
+				m.sources = nil
+				m.mode = modeResults
 				m.searchInput.Blur()
 				return m, fetchCmd(m)
 			case "esc":
@@ -162,21 +311,70 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case key.Matches(msg, m.keys.Search):
 			m.searching = true
+			m.showSaved = false
 			m.searchInput.Focus()
 			return m, nil
+		case key.Matches(msg, m.keys.Saved):
+			if m.showSaved {
+				m.showSaved = false
+				break
+			}
+			return m, loadSavedCmd(m.store, m.storeErr)
+		case m.showSaved && key.Matches(msg, m.keys.Up):
+			if m.savedCursor > 0 {
+				m.savedCursor--
+			}
+		case m.showSaved && key.Matches(msg, m.keys.Down):
+			if m.savedCursor < len(m.saved)-1 {
+				m.savedCursor++
+			}
+		case m.showSaved && key.Matches(msg, m.keys.Enter):
+			if len(m.saved) > 0 {
+				ss := m.saved[m.savedCursor]
+				m.searchInput.SetValue(ss.Query)
+				m.sources = ss.Sources
+				m.showSaved = false
+				m.mode = modeResults
+				m.loading = true
+				m.cursor = 0
+				return m, fetchCmd(m)
+			}
+		case key.Matches(msg, m.keys.Bookmarks), key.Matches(msg, m.keys.History):
+			mode := modeBookmarks
+			if key.Matches(msg, m.keys.History) {
+				mode = modeHistory
+			}
+			m.showSaved = false
+			m.loading = true
+			if m.mode == mode {
+				m.mode = modeResults
+				return m, fetchCmd(m)
+			}
+			m.mode = mode
+			return m, fetchLocalCmd(m.local, mode)
+		case key.Matches(msg, m.keys.HideRead):
+			m.hideRead = !m.hideRead
+			m.cursor = 0
+			m.viewport.YOffset = 0
+		case !m.showSaved && key.Matches(msg, m.keys.Save):
+			if posts := m.visible(); len(posts) > 0 {
+				p := posts[m.cursor]
+				return m, toggleBookmarkCmd(m.local, m.localErr, p, m.bookmarked[p.URL])
+			}
 		case key.Matches(msg, m.keys.Up):
 			if m.cursor > 0 {
 				m.cursor--
 				m.viewport.LineUp(1)
 			}
 		case key.Matches(msg, m.keys.Down):
-			if m.cursor < len(m.posts)-1 {
+			if m.cursor < len(m.visible())-1 {
 				m.cursor++
 				m.viewport.LineDown(1)
 			}
 		case key.Matches(msg, m.keys.Enter):
-			if len(m.posts) > 0 {
-				launchBrowser(m.posts[m.cursor].URL)
+			if posts := m.visible(); len(posts) > 0 {
+				launchBrowser(posts[m.cursor].URL)
+				return m, visitCmd(m.local, m.localErr, posts[m.cursor])
 			}
 		}
 
@@ -200,13 +398,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewport.YOffset = 0
 		m.viewport.SetContent(m.renderGrid())
 
+	case savedMsg:
+		m.saved = msg.searches
+		m.savedErr = msg.err
+		m.savedCursor = 0
+		m.showSaved = true
+		m.viewport.YOffset = 0
+
+	case marksMsg:
+		m.marksErr = msg.err
+		if msg.err == nil {
+			m.bookmarked, m.read = msg.saved, msg.read
+		}
+		// A post just marked read may have dropped out of the grid.
+		if n := len(m.visible()); m.cursor >= n && n > 0 {
+			m.cursor = n - 1
+		}
+
 	case spinner.TickMsg:
 		m.spinner, cmd = m.spinner.Update(msg)
 		cmds = append(cmds, cmd)
 	}
 
 	// Only update content if we aren't loading, to reflect cursor changes
-	if m.ready && !m.loading && len(m.posts) > 0 {
+	if m.ready && m.showSaved {
+		m.viewport.SetContent(m.renderSaved())
+	} else if m.ready && !m.loading {
 		m.viewport.SetContent(m.renderGrid())
 	}
 
@@ -218,8 +435,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) renderGrid() string {
-	if len(m.posts) == 0 {
-		return lipgloss.Place(m.width, 10, lipgloss.Center, lipgloss.Center, "NO_DATA_RETURNED")
+	posts := m.visible()
+	if len(posts) == 0 {
+		empty := "NO_DATA_RETURNED"
+		switch {
+		case m.mode == modeBookmarks:
+			empty = "NO_BOOKMARKS (press s on a result to save it)"
+		case m.mode == modeHistory:
+			empty = "NO_HISTORY"
+		case len(m.posts) > 0:
+			empty = "ALL_RESULTS_READ (press r to show them)"
+		}
+		return lipgloss.Place(m.width, 10, lipgloss.Center, lipgloss.Center, empty)
 	}
 
 	cols := m.width / (cardWidth + 4)
@@ -230,20 +457,36 @@ func (m model) renderGrid() string {
 	var rows []string
 	var currentRow []string
 
-	for i, p := range m.posts {
+	for i, p := range posts {
 		style := cardStyle
 		if i == m.cursor {
 			style = activeStyle
 		}
 
-		meta := lipgloss.NewStyle().Foreground(lipgloss.Color(colorGold)).
-			Render(fmt.Sprintf("%s\n[%s]", p.PublishedAt.Format("02 Jan 2006"), strings.ToUpper(p.Source)))
-		title := lipgloss.NewStyle().Foreground(lipgloss.Color(colorText)).Bold(true).Render(p.Title)
+		mark := ""
+		if m.bookmarked[p.URL] {
+			mark = "  ★"
+		}
+		// Read posts are dimmed, except in the history view where every post is read.
+		metaColor, titleColor := colorGold, colorText
+		if m.read[p.URL] && m.mode != modeHistory {
+			metaColor, titleColor = colorMuted, colorMuted
+		}
+		stats := ""
+		if p.Author != "" {
+			stats = "  @" + p.Author
+		}
+		if p.Score != 0 || p.Comments != 0 {
+			stats += fmt.Sprintf("  ▲%d 💬%d", p.Score, p.Comments)
+		}
+		meta := lipgloss.NewStyle().Foreground(lipgloss.Color(metaColor)).
+			Render(fmt.Sprintf("%s%s\n[%s]%s", p.PublishedAt.Format("02 Jan 2006"), mark, strings.ToUpper(p.Source), stats))
+		title := lipgloss.NewStyle().Foreground(lipgloss.Color(titleColor)).Bold(true).Render(p.Title)
 
 		cardContent := lipgloss.JoinVertical(lipgloss.Left, meta, "\n", title)
 		currentRow = append(currentRow, style.Render(cardContent))
 
-		if len(currentRow) == cols || i == len(m.posts)-1 {
+		if len(currentRow) == cols || i == len(posts)-1 {
 			rowStr := lipgloss.JoinHorizontal(lipgloss.Top, currentRow...)
 			rows = append(rows, lipgloss.PlaceHorizontal(m.width, lipgloss.Center, rowStr))
 			currentRow = []string{}
@@ -252,15 +495,51 @@ func (m model) renderGrid() string {
 	return lipgloss.JoinVertical(lipgloss.Center, rows...)
 }
 
+// renderSaved lists the saved searches the server polls in the background.
+func (m model) renderSaved() string {
+	if m.savedErr != nil {
+		return lipgloss.Place(m.width, 10, lipgloss.Center, lipgloss.Center, "SAVED_SEARCHES_UNAVAILABLE: "+m.savedErr.Error())
+	}
+	if len(m.saved) == 0 {
+		return lipgloss.Place(m.width, 10, lipgloss.Center, lipgloss.Center, "NO_SAVED_SEARCHES (grip-cli searches add <name> <query>)")
+	}
+
+	var lines []string
+	for i, ss := range m.saved {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(colorText))
+		marker := "  "
+		if i == m.savedCursor {
+			style = style.Foreground(lipgloss.Color(colorRose)).Bold(true)
+			marker = "> "
+		}
+		line := fmt.Sprintf("%s%-20s %-30q", marker, ss.Name, ss.Query)
+		if len(ss.Sources) > 0 {
+			line += " [" + strings.Join(ss.Sources, ", ") + "]"
+		}
+		lines = append(lines, style.Render(line))
+	}
+	list := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, list)
+}
+
 func (m model) View() string {
 	if !m.ready {
 		return "\n  Initializing..."
 	}
 
 	latStr := ""
-	if m.latency > 0 {
+	if m.marksErr != nil {
+		latStr = latencyStyle.Render("BOOKMARKS_AND_HISTORY_UNAVAILABLE: " + m.marksErr.Error())
+	} else if m.mode == modeBookmarks {
+		latStr = latencyStyle.Render("BOOKMARKS")
+	} else if m.mode == modeHistory {
+		latStr = latencyStyle.Render("HISTORY")
+	} else if m.latency > 0 {
 		latStr = latencyStyle.Render(fmt.Sprintf("LATENCY: %v", m.latency))
 	}
+	if m.hideRead && m.mode != modeHistory {
+		latStr = latencyStyle.Render("HIDING_READ  ") + latStr
+	}
 	topBar := lipgloss.PlaceHorizontal(m.width, lipgloss.Right, latStr)
 
 	largeTitle := titleStyle.Render(" ██████╗ ██████╗ ██╗██████╗ \n ██╔════╝ ██╔══██╗██║██╔══██╗\n ██║  ███╗██████╔╝██║██████╔╝\n ██║   ██║██╔══██╗██║██╔═══╝ \n ╚██████╔╝██║  ██║██║██║     \n  ╚═════╝ ╚═╝  ╚═╝╚═╝╚═╝     ")
@@ -300,12 +579,26 @@ func launchBrowser(url string) {
 }
 
 func main() {
+	configPath := flag.String("config", config.PathFromEnv(), "path to the JSON config file (env GRIP_CONFIG)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath, config.Default())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	client := &http.Client{Timeout: 10 * time.Second}
-	engine := logic.NewEngine([]logic.Source{
-		&sources.DevTo{Client: client}, &sources.HackerNews{Client: client},
-		&sources.Hashnode{Client: client}, &sources.BootDev{Client: client},
-		&sources.Lobsters{Client: client}, &sources.FreeCodeCamp{Client: client},
-	})
+	engine := logic.NewEngine(sources.FromConfig(cfg.Sources, client))
+
+	// Saved searches are optional here; the list shows the error if the store cannot be opened.
+	st, storeErr := store.Open(cfg.Store.Path)
+	// Bookmarks live in the per-user local store; the header shows the error if it cannot be opened.
+	var local *store.Store
+	path, localErr := cfg.Store.Local()
+	if localErr == nil {
+		local, localErr = store.Open(path)
+	}
 
 	ti := textinput.New()
 	ti.Placeholder = "type and press enter..."
@@ -322,6 +615,10 @@ func main() {
 
 	m := model{
 		engine:      engine,
+		store:       st,
+		storeErr:    storeErr,
+		local:       local,
+		localErr:    localErr,
 		loading:     true,
 		spinner:     spin,
 		searchInput: ti,
@@ -17,54 +17,1054 @@ const docTemplate = `{
     "paths": {
         "/": {
             "get": {
-                "description": "Returns the top 20 newest posts.\nIMPORTANT: You must set the 'Accept: application/json' header to receive JSON.\nWithout this header, the server will default to serving the HTML template.",
+                "description": "Returns the top 20 newest posts.\nThe response format is negotiated from the Accept header: JSON is served when\napplication/json is preferred over text/html, otherwise the HTML template is rendered.\nNew integrations should use /api/v1/search instead.",
                 "produces": [
                     "application/json",
                     "text/html"
                 ],
+                "tags": [
+                    "ui"
+                ],
                 "summary": "Search Aggregated Blogs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search Keyword (defaults to 'golang')",
-                        "name": "q",
+                        "description": "Search Keyword (defaults to 'golang')",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "saved",
+                            "history"
+                        ],
+                        "type": "string",
+                        "description": "Show bookmarks or reading history instead of search results",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out posts that have already been opened",
+                        "name": "hide_read",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved posts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/logic.Post"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found: Only the root path '/' is supported",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "description": "Returns raw search results as JSON",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (defaults to 'golang')",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of posts (1-100, defaults to 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only query these sources (comma separated or repeated)",
+                        "name": "sources",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/logic.Post"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_query or unknown_source",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/bookmarks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmarks"
+                ],
+                "summary": "List bookmarks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookmarkList"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Saving a post that is already bookmarked returns the existing bookmark with 200.\nAt most 1000 posts can be bookmarked; remove some to save more.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmarks"
+                ],
+                "summary": "Bookmark a post",
+                "parameters": [
+                    {
+                        "description": "Post to save, as returned by the search API",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/logic.Post"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Already bookmarked",
+                        "schema": {
+                            "$ref": "#/definitions/store.Bookmark"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: API keys are enabled and none was sent",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: the bookmark limit is reached",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/bookmarks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "bookmarks"
+                ],
+                "summary": "Remove a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "unauthorized: API keys are enabled and none was sent",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "List reading history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of visits (1-500, defaults to 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HistoryList"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_query",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Clients call this when the user opens a post, so later results can be marked as read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "Record an opened post",
+                "parameters": [
+                    {
+                        "description": "Opened post, as returned by the search API",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/logic.Post"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Visit"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: API keys are enabled and none was sent",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "Clear reading history",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "unauthorized: API keys are enabled and none was sent",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns a page of the newest posts with per-source status and a cursor for the next page.\nPass next_cursor back as 'after' to fetch the following page.",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search posts (v1)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (defaults to 'golang')",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-100, defaults to 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous response's next_cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only query these sources (comma separated or repeated)",
+                        "name": "sources",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_query or unknown_source",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: missing, invalid or revoked API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "not_acceptable: the Accept header does not allow application/json",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "all_sources_failed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/searches": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "List saved searches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SavedSearchList"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The scheduler polls saved searches on an interval. The first poll records a baseline,\nand every later poll flags posts the search has not seen before.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "Create a saved search",
+                "parameters": [
+                    {
+                        "description": "Saved search",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SavedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_request, invalid_query or unknown_source",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: API keys are enabled and none was sent",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: a saved search with this name exists",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "rate_limited",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/searches/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "Get a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.SavedSearch"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "Delete a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "unauthorized: API keys are enabled and none was sent",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/searches/{name}/new": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the posts background polling found for the first time, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "searches"
+                ],
+                "summary": "New posts for a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only matches found after this RFC 3339 time",
+                        "name": "since",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved posts",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/logic.Post"
-                            }
+                            "$ref": "#/definitions/handlers.MatchList"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_query: since is not an RFC 3339 time",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
                         }
                     },
                     "404": {
-                        "description": "Not Found: Only the root path '/' is supported",
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed.json": {
+            "get": {
+                "description": "Renders the newest posts for q as a JSON Feed 1.1 document.",
+                "produces": [
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Search results as JSON Feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (defaults to 'golang')",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries (1-100, defaults to 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only query these sources (comma separated or repeated)",
+                        "name": "sources",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed document",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "invalid_query or unknown_source",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed.xml": {
+            "get": {
+                "description": "Renders the newest posts for q as Atom 1.0 by default. Pass format=rss, or prefer\napplication/rss+xml in the Accept header, to get RSS 2.0 instead.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Search results as Atom or RSS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (defaults to 'golang')",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "atom",
+                            "rss"
+                        ],
+                        "type": "string",
+                        "description": "Feed format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries (1-100, defaults to 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only query these sources (comma separated or repeated)",
+                        "name": "sources",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed document",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "400": {
+                        "description": "invalid_query or unknown_source",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthStatus"
+                        }
                     }
                 }
             }
         }
     },
     "definitions": {
+        "handlers.BookmarkList": {
+            "type": "object",
+            "properties": {
+                "api_version": {
+                    "type": "string",
+                    "example": "v1"
+                },
+                "bookmarks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.Bookmark"
+                    }
+                }
+            }
+        },
+        "handlers.HealthStatus": {
+            "type": "object",
+            "properties": {
+                "sources": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "handlers.HistoryList": {
+            "type": "object",
+            "properties": {
+                "api_version": {
+                    "type": "string",
+                    "example": "v1"
+                },
+                "visits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.Visit"
+                    }
+                }
+            }
+        },
+        "handlers.MatchList": {
+            "type": "object",
+            "properties": {
+                "api_version": {
+                    "type": "string",
+                    "example": "v1"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.Match"
+                    }
+                },
+                "search": {
+                    "type": "string",
+                    "example": "golang"
+                }
+            }
+        },
+        "handlers.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "enum": [
+                        "invalid_query",
+                        "invalid_request",
+                        "unknown_source",
+                        "all_sources_failed",
+                        "unauthorized",
+                        "rate_limited",
+                        "not_found",
+                        "conflict",
+                        "method_not_allowed",
+                        "not_acceptable",
+                        "internal_error"
+                    ],
+                    "example": "invalid_query"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "limit must be a number between 1 and 100"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/search?limit=500"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "https://github.com/Numpkens/grip/blob/main/docs/errors.md#invalid_query"
+                }
+            }
+        },
+        "handlers.SavedSearchList": {
+            "type": "object",
+            "properties": {
+                "api_version": {
+                    "type": "string",
+                    "example": "v1"
+                },
+                "searches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.SavedSearch"
+                    }
+                }
+            }
+        },
+        "handlers.SavedSearchRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "golang"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Dev.to",
+                        "Lobsters"
+                    ]
+                }
+            }
+        },
+        "handlers.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "api_version": {
+                    "type": "string",
+                    "example": "v1"
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 471
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "MTc2ODk5MDQwMDAwMDAwMDAwMHxodHRwczovL2Rldi50by91c2VyL3Bvc3Q"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/logic.Post"
+                    }
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/logic.SourceStatus"
+                    }
+                }
+            }
+        },
         "logic.Post": {
             "type": "object",
             "properties": {
+                "author": {
+                    "description": "Author is the writer or submitter, when the source names one.",
+                    "type": "string",
+                    "example": "gopher"
+                },
+                "comments": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "discussion_url": {
+                    "type": "string",
+                    "example": "https://www.reddit.com/r/golang/comments/abc123/"
+                },
                 "published_at": {
                     "type": "string",
                     "example": "2026-01-21T10:00:00Z"
                 },
+                "score": {
+                    "description": "Score, Comments and DiscussionURL are only set by sources with community\ndiscussion, such as Reddit. Comments counts replies, which are answers\non Q\u0026A sites.",
+                    "type": "integer",
+                    "example": 42
+                },
                 "source": {
                     "type": "string",
                     "example": "dev.to"
@@ -78,6 +1078,127 @@ const docTemplate = `{
                     "example": "https://dev.to/user/post"
                 }
             }
+        },
+        "logic.SourceStatus": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "error": {
+                    "type": "string",
+                    "example": "devto api error: status 503"
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 312
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dev.to"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "error",
+                        "timeout"
+                    ],
+                    "example": "ok"
+                }
+            }
+        },
+        "store.Bookmark": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID is derived from the post URL, so saving the same post twice is a no-op.",
+                    "type": "string",
+                    "example": "3f1a9c0e5b7d2468"
+                },
+                "post": {
+                    "$ref": "#/definitions/logic.Post"
+                },
+                "saved_at": {
+                    "type": "string",
+                    "example": "2026-01-21T10:00:00Z"
+                }
+            }
+        },
+        "store.Match": {
+            "type": "object",
+            "properties": {
+                "found_at": {
+                    "type": "string",
+                    "example": "2026-01-21T10:15:00Z"
+                },
+                "post": {
+                    "$ref": "#/definitions/logic.Post"
+                },
+                "search": {
+                    "type": "string",
+                    "example": "golang"
+                }
+            }
+        },
+        "store.SavedSearch": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-21T10:00:00Z"
+                },
+                "last_polled_at": {
+                    "description": "LastPolledAt is zero until the first poll has recorded a baseline.",
+                    "type": "string",
+                    "example": "2026-01-21T10:15:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "golang"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang"
+                },
+                "sources": {
+                    "description": "Sources restricts the search like SearchOptions.Sources; empty means all.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Dev.to",
+                        "Lobsters"
+                    ]
+                }
+            }
+        },
+        "store.Visit": {
+            "type": "object",
+            "properties": {
+                "opened_at": {
+                    "type": "string",
+                    "example": "2026-01-21T10:00:00Z"
+                },
+                "opens": {
+                    "description": "Opens counts every time the post was opened, so re-reads are visible.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "post": {
+                    "$ref": "#/definitions/logic.Post"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Required only when server.auth is enabled; \"Authorization: Bearer \u003ckey\u003e\" also works.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,438 @@
+// Package config holds the runtime settings shared by the GRIP entry points.
+// Settings come from built-in defaults, an optional JSON file and environment
+// overrides, in that order.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// DefaultPath is the config file read when no -config flag or GRIP_CONFIG is set.
+const DefaultPath = "grip.json"
+
+// Config is the root of the GRIP configuration file.
+type Config struct {
+	Server  Server  `json:"server"`
+	GRPC    GRPC    `json:"grpc"`
+	Sources Sources `json:"sources"`
+	Store   Store   `json:"store"`
+	Watch   Watch   `json:"watch"`
+	// Webhooks are notified when a saved search finds new posts.
+	Webhooks []Webhook `json:"webhooks,omitempty"`
+	Digest   Digest    `json:"digest"`
+}
+
+// Digest configures the email digest sent by grip-cli digest.
+type Digest struct {
+	From       string      `json:"from"`
+	Subject    string      `json:"subject"`
+	Recipients []Recipient `json:"recipients,omitempty"`
+	SMTP       SMTP        `json:"smtp"`
+}
+
+// Recipient is one digest subscriber.
+type Recipient struct {
+	Email string `json:"email"`
+	// Searches limits the digest to these saved searches; empty means all of them.
+	Searches []string `json:"searches,omitempty"`
+}
+
+// SMTP is the outgoing mail server. STARTTLS is used whenever the server offers it.
+type SMTP struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username,omitempty"`
+	// Password falls back to the GRIP_SMTP_PASSWORD environment variable so it
+	// can stay out of the file.
+	Password string `json:"password,omitempty"`
+}
+
+// Webhook is an outbound endpoint for saved-search matches.
+type Webhook struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Format selects the payload: "json" (the default), "slack" or "discord".
+	Format string `json:"format,omitempty"`
+	// Secret, when set, signs each body with HMAC-SHA256 in X-Grip-Signature.
+	Secret string `json:"secret,omitempty"`
+	// Searches limits the hook to these saved searches; empty means all of them.
+	Searches []string `json:"searches,omitempty"`
+}
+
+// Store locates the JSON files holding GRIP's state.
+type Store struct {
+	// Path is the server's store, holding saved searches, API keys and web bookmarks.
+	Path string `json:"path"`
+	// LocalPath is the per-user store of grip-cli and grip-tui. Empty means
+	// grip/local.json under the user's config directory.
+	LocalPath string `json:"local_path,omitempty"`
+}
+
+// Local returns LocalPath or its default location.
+func (s Store) Local() (string, error) {
+	if s.LocalPath != "" {
+		return s.LocalPath, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "grip", "local.json"), nil
+}
+
+// Watch configures background polling of saved searches.
+type Watch struct {
+	// Interval between polls of every saved search. Zero disables polling.
+	Interval Duration `json:"interval"`
+}
+
+// Duration is a time.Duration written as a Go duration string ("15m") in JSON.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"15m\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Sources configures providers on top of the built-in adapters.
+type Sources struct {
+	// Feeds are arbitrary RSS or Atom feeds searched by title.
+	Feeds         []Feed        `json:"feeds,omitempty"`
+	DevTo         DevTo         `json:"devto"`
+	HackerNews    HackerNews    `json:"hackernews"`
+	Reddit        Reddit        `json:"reddit"`
+	GitHub        GitHub        `json:"github"`
+	Medium        Medium        `json:"medium"`
+	Substack      Substack      `json:"substack"`
+	StackExchange StackExchange `json:"stackexchange"`
+	Mastodon      Mastodon      `json:"mastodon"`
+}
+
+// DevTo tunes the built-in Dev.to source. Top and State only apply to
+// one-word queries, which list a tag; longer queries are searched.
+type DevTo struct {
+	// Top lists the most popular articles of the last Top days.
+	Top int `json:"top,omitempty"`
+	// State is "rising" or "fresh".
+	State   string `json:"state,omitempty"`
+	PerPage int    `json:"per_page,omitempty"`
+	Page    int    `json:"page,omitempty"`
+}
+
+// HackerNews tunes the built-in Hacker News source.
+type HackerNews struct {
+	// Sort is "relevance" (the default) or "date".
+	Sort      string `json:"sort,omitempty"`
+	MinPoints int    `json:"min_points,omitempty"`
+	// Kind limits results to "show" (Show HN) or "ask" (Ask HN) posts.
+	Kind string `json:"kind,omitempty"`
+}
+
+// Mastodon configures the Mastodon source, which reads the hashtag timeline
+// named by the query. It is off until Instances lists at least one server.
+type Mastodon struct {
+	// Instances are host names such as "hachyderm.io", or base URLs.
+	Instances []string `json:"instances,omitempty"`
+}
+
+// Medium configures the Medium source, which reads the tag feed named by the
+// query plus the feeds of Publications. It is off unless Enabled is set.
+type Medium struct {
+	Enabled bool `json:"enabled"`
+	// Publications are publication slugs or "@user" names.
+	Publications []string `json:"publications,omitempty"`
+}
+
+// StackExchange configures the Stack Exchange questions source. It is off
+// until Sites lists at least one API site name, such as "stackoverflow".
+type StackExchange struct {
+	Sites    []string `json:"sites,omitempty"`
+	MinScore int      `json:"min_score,omitempty"`
+	// Key is an app key from stackapps.com, raising the daily quota from 300 to 10,000.
+	Key string `json:"key,omitempty"`
+}
+
+// Substack configures the Substack source. It is off until Publications
+// lists a subdomain, custom domain or URL.
+type Substack struct {
+	Publications []string `json:"publications,omitempty"`
+}
+
+// GitHub configures the GitHub releases source. It is off until Repos lists
+// at least one "owner/name".
+type GitHub struct {
+	Repos []string `json:"repos,omitempty"`
+	// Discussions also searches the repos' discussions, which needs Token.
+	Discussions bool `json:"discussions,omitempty"`
+	// Token raises the rate limit from 60 to 5000 requests an hour.
+	Token string `json:"token,omitempty"`
+}
+
+// Reddit configures the Reddit source. It is off until Subreddits lists at
+// least one subreddit.
+type Reddit struct {
+	Subreddits []string `json:"subreddits"`
+	// UserAgent identifies GRIP to Reddit, which throttles generic agents.
+	// Reddit asks for "<platform>:<app id>:<version> (by /u/<username>)".
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// Feed is a single RSS or Atom subscription.
+type Feed struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Site is the feed's HTML home page, kept so OPML exports round-trip.
+	Site string `json:"site,omitempty"`
+}
+
+// GRPC configures the grip-grpc service.
+type GRPC struct {
+	Addr string `json:"addr"`
+}
+
+// Server controls which parts of the HTTP router are mounted and where it listens.
+type Server struct {
+	Addr         string   `json:"addr"`
+	TemplatePath string   `json:"template_path"`
+	StaticDir    string   `json:"static_dir"`
+	Features     Features `json:"features"`
+	// TrustProxy uses the first X-Forwarded-For address as the client IP, and
+	// X-Forwarded-Proto and X-Forwarded-Host for the links in feeds, which is
+	// only safe behind a proxy that sets the headers itself.
+	TrustProxy bool      `json:"trust_proxy"`
+	RateLimit  RateLimit `json:"rate_limit"`
+	Auth       Auth      `json:"auth"`
+	CORS       CORS      `json:"cors"`
+}
+
+// CORS controls cross-origin access to the API, GraphQL, feed and home routes.
+type CORS struct {
+	// AllowedOrigins lists exact origins such as "https://example.com", or "*"
+	// for any origin. Empty sends no CORS headers at all.
+	AllowedOrigins   []string `json:"allowed_origins"`
+	AllowedMethods   []string `json:"allowed_methods"`
+	AllowedHeaders   []string `json:"allowed_headers"`
+	ExposedHeaders   []string `json:"exposed_headers,omitempty"`
+	AllowCredentials bool     `json:"allow_credentials"`
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge Duration `json:"max_age"`
+}
+
+// Auth controls API-key authentication on the API and GraphQL routes.
+type Auth struct {
+	Enabled bool `json:"enabled"`
+	// AllowAnonymous lets requests without a key through, throttled per client
+	// by RateLimit. Requests with an invalid or revoked key are always rejected.
+	AllowAnonymous bool `json:"allow_anonymous"`
+	// KeyRateLimit throttles each key that does not set its own limit.
+	KeyRateLimit RateLimit `json:"key_rate_limit"`
+}
+
+// RateLimit throttles each client on the API routes. Zero RequestsPerMinute disables it.
+type RateLimit struct {
+	RequestsPerMinute int `json:"requests_per_minute"`
+	Burst             int `json:"burst"`
+}
+
+// Features toggles each group of routes on the unified server.
+type Features struct {
+	UI      bool `json:"ui"`
+	API     bool `json:"api"`
+	GraphQL bool `json:"graphql"`
+	Feeds   bool `json:"feeds"`
+	Swagger bool `json:"swagger"`
+	Static  bool `json:"static"`
+	Health  bool `json:"health"`
+}
+
+// Default returns a config with every feature enabled.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Addr:         ":8080",
+			TemplatePath: "templates/index.html",
+			StaticDir:    "static",
+			Features: Features{
+				UI:      true,
+				API:     true,
+				GraphQL: true,
+				Feeds:   true,
+				Swagger: true,
+				Static:  true,
+				Health:  true,
+			},
+			RateLimit: RateLimit{
+				RequestsPerMinute: 120,
+				Burst:             30,
+			},
+			Auth: Auth{
+				AllowAnonymous: true,
+				KeyRateLimit: RateLimit{
+					RequestsPerMinute: 600,
+					Burst:             60,
+				},
+			},
+			CORS: CORS{
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET", "POST", "DELETE"},
+				AllowedHeaders: []string{"Accept", "Content-Type", "Authorization", "X-API-Key"},
+				ExposedHeaders: []string{"Retry-After"},
+				MaxAge:         Duration(10 * time.Minute),
+			},
+		},
+		GRPC: GRPC{
+			Addr: ":9090",
+		},
+		Store: Store{
+			Path: "grip-data.json",
+		},
+		Watch: Watch{
+			Interval: Duration(15 * time.Minute),
+		},
+		Digest: Digest{
+			From:    "grip@localhost",
+			Subject: "Your GRIP digest",
+			SMTP: SMTP{
+				Host: "localhost",
+				Port: 587,
+			},
+		},
+	}
+}
+
+// PathFromEnv returns GRIP_CONFIG or DefaultPath.
+func PathFromEnv() string {
+	if p := os.Getenv("GRIP_CONFIG"); p != "" {
+		return p
+	}
+	return DefaultPath
+}
+
+// Load reads the JSON file at path on top of base and applies environment
+// overrides. A missing file is not an error, so the binaries run with their
+// defaults out of the box.
+func Load(path string, base Config) (Config, error) {
+	cfg, err := Read(path, base)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.applyEnv()
+	return cfg, nil
+}
+
+// Read is Load without the environment overrides, for tools that edit the
+// file and must not write the environment back into it.
+func Read(path string, base Config) (Config, error) {
+	cfg := base
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate rejects values that the sources would otherwise silently ignore.
+func (c Config) Validate() error {
+	switch c.Sources.DevTo.State {
+	case "", "rising", "fresh":
+	default:
+		return fmt.Errorf("sources.devto.state: unknown value %q (want rising or fresh)", c.Sources.DevTo.State)
+	}
+	switch c.Sources.HackerNews.Sort {
+	case "", "relevance", "date":
+	default:
+		return fmt.Errorf("sources.hackernews.sort: unknown value %q (want relevance or date)", c.Sources.HackerNews.Sort)
+	}
+	switch c.Sources.HackerNews.Kind {
+	case "", "show", "ask":
+	default:
+		return fmt.Errorf("sources.hackernews.kind: unknown value %q (want show or ask)", c.Sources.HackerNews.Kind)
+	}
+	// Feeds are selected by name, which the engine matches loosely, so two
+	// names that only differ in case or punctuation would shadow each other.
+	seen := make(map[string]string)
+	for i, f := range c.Sources.Feeds {
+		key := logic.SourceKey(f.Name)
+		switch {
+		case key == "":
+			return fmt.Errorf("sources.feeds[%d]: name is required", i)
+		case f.URL == "":
+			return fmt.Errorf("sources.feeds[%d] %q: url is required", i, f.Name)
+		case seen[key] != "":
+			return fmt.Errorf("sources.feeds[%d]: name %q clashes with %q", i, f.Name, seen[key])
+		}
+		seen[key] = f.Name
+	}
+	return nil
+}
+
+// Save writes cfg to path as indented JSON, replacing the file atomically.
+func Save(path string, cfg Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".grip-config-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// applyEnv lets container platforms override the listen addresses and secrets without a file.
+func (c *Config) applyEnv() {
+	if addr := os.Getenv("GRIP_GRPC_ADDR"); addr != "" {
+		c.GRPC.Addr = addr
+	}
+	if pw := os.Getenv("GRIP_SMTP_PASSWORD"); pw != "" {
+		c.Digest.SMTP.Password = pw
+	}
+	if token := os.Getenv("GRIP_GITHUB_TOKEN"); token != "" {
+		c.Sources.GitHub.Token = token
+	}
+	if addr := os.Getenv("GRIP_ADDR"); addr != "" {
+		c.Server.Addr = addr
+	} else if port := os.Getenv("PORT"); port != "" {
+		c.Server.Addr = ":" + port
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadMissingFileUsesDefaults(t *testing.T) {
+	t.Setenv("GRIP_ADDR", "")
+	t.Setenv("PORT", "")
+
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.json"), Default())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("expected defaults, got %+v", cfg)
+	}
+}
+
+func TestLoadOverridesAndEnv(t *testing.T) {
+	t.Setenv("GRIP_ADDR", "")
+	t.Setenv("PORT", "3000")
+
+	path := filepath.Join(t.TempDir(), "grip.json")
+	os.WriteFile(path, []byte(`{"server": {"features": {"ui": false}}}`), 0o644)
+
+	cfg, err := Load(path, Default())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Server.Features.UI {
+		t.Error("expected the file to disable the UI")
+	}
+	if !cfg.Server.Features.API {
+		t.Error("expected untouched features to keep their defaults")
+	}
+	if cfg.Server.Addr != ":3000" {
+		t.Errorf("expected PORT to set the address, got %s", cfg.Server.Addr)
+	}
+}
+
+func TestLoadWatchInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grip.json")
+	os.WriteFile(path, []byte(`{"watch": {"interval": "1h30m"}}`), 0o644)
+
+	cfg, err := Read(path, Default())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := time.Duration(cfg.Watch.Interval); got != 90*time.Minute {
+		t.Errorf("expected 1h30m, got %s", got)
+	}
+
+	os.WriteFile(path, []byte(`{"watch": {"interval": 60}}`), 0o644)
+	if _, err := Read(path, Default()); err == nil {
+		t.Error("expected a bare number to be rejected")
+	}
+}
+
+func TestReadRejectsUnknownEnums(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grip.json")
+	for _, body := range []string{
+		`{"sources": {"hackernews": {"sort": "newest"}}}`,
+		`{"sources": {"hackernews": {"kind": "jobs"}}}`,
+		`{"sources": {"devto": {"state": "hot"}}}`,
+	} {
+		os.WriteFile(path, []byte(body), 0o644)
+		if _, err := Read(path, Default()); err == nil {
+			t.Errorf("expected %s to be rejected", body)
+		}
+	}
+
+	os.WriteFile(path, []byte(`{"sources": {"hackernews": {"sort": "date", "kind": "show"}}}`), 0o644)
+	if _, err := Read(path, Default()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReadRejectsBadFeeds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grip.json")
+	for _, body := range []string{
+		`{"sources": {"feeds": [{"url": "https://go.dev/blog/feed.atom"}]}}`,
+		`{"sources": {"feeds": [{"name": "Go Blog"}]}}`,
+		`{"sources": {"feeds": [{"name": "Go Blog", "url": "https://go.dev/blog/feed.atom"}, {"name": "go-blog", "url": "https://example.com/feed"}]}}`,
+	} {
+		os.WriteFile(path, []byte(body), 0o644)
+		if _, err := Read(path, Default()); err == nil {
+			t.Errorf("expected %s to be rejected", body)
+		}
+	}
+
+	os.WriteFile(path, []byte(`{"sources": {"feeds": [{"name": "Go Blog", "url": "https://go.dev/blog/feed.atom"}, {"name": "Gopher Academy", "url": "https://example.com/feed"}]}}`), 0o644)
+	if _, err := Read(path, Default()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+// Package digest renders the posts that saved searches have flagged as new
+// into a multipart HTML and plain-text email, and sends it over SMTP.
+package digest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var funcs = map[string]any{
+	"date": func(t time.Time) string { return t.Local().Format("02 Jan 2006 15:04") },
+}
+
+var (
+	htmlTmpl = htmltemplate.Must(htmltemplate.New("digest.html").Funcs(funcs).ParseFS(templateFS, "templates/digest.html"))
+	textTmpl = texttemplate.Must(texttemplate.New("digest.txt").Funcs(funcs).ParseFS(templateFS, "templates/digest.txt"))
+)
+
+// Digest is the content of one recipient's email.
+type Digest struct {
+	To      string
+	Subject string
+	// Since and Until bound the FoundAt time of every match included.
+	Since    time.Time
+	Until    time.Time
+	Sections []Section
+}
+
+// Section groups the new posts of one saved search.
+type Section struct {
+	Search  store.SavedSearch
+	Matches []store.Match
+}
+
+// Count is the number of posts across all sections.
+func (d Digest) Count() int {
+	n := 0
+	for _, s := range d.Sections {
+		n += len(s.Matches)
+	}
+	return n
+}
+
+// Build collects the matches found in (since, until] for the saved searches r
+// subscribes to. Searches without matches are left out.
+func Build(st *store.Store, r config.Recipient, subject string, since, until time.Time) (Digest, error) {
+	d := Digest{To: r.Email, Subject: subject, Since: since, Until: until}
+
+	searches, err := st.Searches()
+	if err != nil {
+		return d, err
+	}
+	for _, ss := range searches {
+		if len(r.Searches) > 0 && !slices.Contains(r.Searches, ss.Name) {
+			continue
+		}
+		matches, err := st.Matches(ss.Name, since)
+		if err != nil {
+			return d, err
+		}
+		matches = slices.DeleteFunc(matches, func(m store.Match) bool { return m.FoundAt.After(until) })
+		if len(matches) > 0 {
+			d.Sections = append(d.Sections, Section{Search: ss, Matches: matches})
+		}
+	}
+	return d, nil
+}
+
+// Render returns d as a complete RFC 5322 message with a text/plain part and
+// a text/html alternative.
+func Render(d Digest, from string) ([]byte, error) {
+	var html, text bytes.Buffer
+	if err := htmlTmpl.Execute(&html, d); err != nil {
+		return nil, err
+	}
+	if err := textTmpl.Execute(&text, d); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		// Clients show the last alternative they understand, so HTML goes last.
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	id, err := messageID(from)
+	if err != nil {
+		return nil, err
+	}
+	var msg bytes.Buffer
+	header := []struct{ key, value string }{
+		{"From", from},
+		{"To", d.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", d.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", id},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + mw.Boundary()},
+	}
+	for _, h := range header {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.key, h.value)
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// Send delivers msg to the given recipients through the configured server.
+// from may include a display name. Authentication is only attempted when a
+// username is set, and net/smtp refuses to send credentials over an
+// unencrypted remote connection.
+func Send(cfg config.SMTP, from string, to []string, msg []byte) error {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return fmt.Errorf("digest from address: %w", err)
+	}
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return smtp.SendMail(addr, auth, sender.Address, to, msg)
+}
+
+func messageID(from string) (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating message ID: %w", err)
+	}
+	domain := "grip.local"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndexByte(addr.Address, '@'); at >= 0 {
+			domain = addr.Address[at+1:]
+		}
+	}
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">", nil
+}
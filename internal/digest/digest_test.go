@@ -0,0 +1,110 @@
+package digest
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+func seededStore(t *testing.T) (*store.Store, time.Time) {
+	t.Helper()
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"golang", "htmx"} {
+		if _, err := st.AddSearch(store.SavedSearch{Name: name, Query: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t0 := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	st.RecordPoll("golang", nil, t0)
+	st.RecordPoll("htmx", nil, t0)
+	st.RecordPoll("golang", []logic.Post{{Title: "Generics & <you>", URL: "https://a", Source: "Dev.to"}}, t0.Add(time.Hour))
+	st.RecordPoll("htmx", []logic.Post{{Title: "htmx 3", URL: "https://b", Source: "Lobsters"}}, t0.Add(2*time.Hour))
+	return st, t0
+}
+
+func TestBuildFiltersBySubscriptionAndWindow(t *testing.T) {
+	st, t0 := seededStore(t)
+
+	d, err := Build(st, config.Recipient{Email: "a@example.com"}, "Digest", t0, t0.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Count() != 2 || len(d.Sections) != 2 {
+		t.Errorf("expected both searches, got %+v", d.Sections)
+	}
+
+	d, _ = Build(st, config.Recipient{Email: "a@example.com", Searches: []string{"htmx"}}, "Digest", t0, t0.Add(24*time.Hour))
+	if d.Count() != 1 || d.Sections[0].Search.Name != "htmx" {
+		t.Errorf("expected only htmx, got %+v", d.Sections)
+	}
+
+	d, _ = Build(st, config.Recipient{Email: "a@example.com"}, "Digest", t0, t0.Add(90*time.Minute))
+	if d.Count() != 1 || d.Sections[0].Search.Name != "golang" {
+		t.Errorf("expected until to cut off the htmx match, got %+v", d.Sections)
+	}
+}
+
+func TestRenderMultipart(t *testing.T) {
+	st, t0 := seededStore(t)
+	d, err := Build(st, config.Recipient{Email: "a@example.com"}, "Your GRIP digest", t0, t0.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := Render(d, "GRIP <grip@example.com>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("not a valid message: %v", err)
+	}
+	if msg.Header.Get("To") != "a@example.com" || msg.Header.Get("Subject") != "Your GRIP digest" {
+		t.Errorf("unexpected headers: %v", msg.Header)
+	}
+	if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>") {
+		t.Errorf("unexpected Message-ID %q", msg.Header.Get("Message-ID"))
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type %q", msg.Header.Get("Content-Type"))
+	}
+
+	parts := map[string]string{}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(p) // NextPart decodes quoted-printable
+		ct, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		parts[ct] = string(body)
+	}
+
+	if text := parts["text/plain"]; !strings.Contains(text, "* Generics & <you>") || !strings.Contains(text, "https://b") {
+		t.Errorf("unexpected text part:\n%s", text)
+	}
+	if html := parts["text/html"]; !strings.Contains(html, "Generics &amp; &lt;you&gt;") || !strings.Contains(html, `href="https://a"`) {
+		t.Errorf("expected escaped HTML part:\n%s", html)
+	}
+}
@@ -0,0 +1,231 @@
+// Package feed renders engine results as syndication feeds so a GRIP query
+// can be followed from any feed reader. It supports Atom 1.0, RSS 2.0 and
+// JSON Feed 1.1.
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// Content types for each output format.
+const (
+	MIMEAtom = "application/atom+xml"
+	MIMERSS  = "application/rss+xml"
+	MIMEJSON = "application/feed+json"
+)
+
+// Feed describes one rendered search.
+type Feed struct {
+	Title       string
+	Description string
+	// SelfURL is the absolute URL the feed was fetched from.
+	SelfURL string
+	// HomeURL is the absolute URL of the matching HTML page.
+	HomeURL string
+	Posts   []logic.Post
+}
+
+// updated returns the newest post date, which readers use to detect changes.
+func (f Feed) updated() time.Time {
+	var newest time.Time
+	for _, p := range f.Posts {
+		if p.PublishedAt.After(newest) {
+			newest = p.PublishedAt
+		}
+	}
+	if newest.IsZero() {
+		return time.Now().UTC()
+	}
+	return newest.UTC()
+}
+
+// entryDate falls back to the feed date for posts whose source sent no timestamp.
+func (f Feed) entryDate(p logic.Post) time.Time {
+	if p.PublishedAt.IsZero() {
+		return f.updated()
+	}
+	return p.PublishedAt.UTC()
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomPerson  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID        string       `xml:"id"`
+	Title     string       `xml:"title"`
+	Updated   string       `xml:"updated"`
+	Published string       `xml:"published"`
+	Link      atomLink     `xml:"link"`
+	Author    atomPerson   `xml:"author"`
+	Category  atomCategory `xml:"category"`
+}
+
+// WriteAtom renders f as an Atom 1.0 document.
+func WriteAtom(w io.Writer, f Feed) error {
+	out := atomFeed{
+		ID:       f.SelfURL,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  f.updated().Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: MIMEAtom, Href: f.SelfURL},
+			{Rel: "alternate", Type: "text/html", Href: f.HomeURL},
+		},
+		Author: atomPerson{Name: "GRIP"},
+	}
+	for _, p := range f.Posts {
+		date := f.entryDate(p).Format(time.RFC3339)
+		out.Entries = append(out.Entries, atomEntry{
+			ID:        p.URL,
+			Title:     p.Title,
+			Updated:   date,
+			Published: date,
+			Link:      atomLink{Rel: "alternate", Href: p.URL},
+			Author:    atomPerson{Name: p.Source},
+			Category:  atomCategory{Term: p.Source},
+		})
+	}
+	return writeXML(w, out)
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Generator     string    `xml:"generator"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title    string  `xml:"title"`
+	Link     string  `xml:"link"`
+	GUID     rssGUID `xml:"guid"`
+	PubDate  string  `xml:"pubDate"`
+	Category string  `xml:"category"`
+}
+
+// WriteRSS renders f as an RSS 2.0 document.
+func WriteRSS(w io.Writer, f Feed) error {
+	out := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.HomeURL,
+			Description:   f.Description,
+			LastBuildDate: f.updated().Format(time.RFC1123Z),
+			Generator:     "GRIP",
+			AtomLink:      atomLink{Rel: "self", Type: MIMERSS, Href: f.SelfURL},
+		},
+	}
+	for _, p := range f.Posts {
+		out.Channel.Items = append(out.Channel.Items, rssItem{
+			Title:    p.Title,
+			Link:     p.URL,
+			GUID:     rssGUID{IsPermaLink: true, Value: p.URL},
+			PubDate:  f.entryDate(p).Format(time.RFC1123Z),
+			Category: p.Source,
+		})
+	}
+	return writeXML(w, out)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(v)
+}
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	HomePageURL string     `json:"home_page_url"`
+	FeedURL     string     `json:"feed_url"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url"`
+	Title         string       `json:"title"`
+	ContentText   string       `json:"content_text"`
+	DatePublished string       `json:"date_published"`
+	Authors       []jsonAuthor `json:"authors"`
+	Tags          []string     `json:"tags"`
+}
+
+// WriteJSON renders f as a JSON Feed 1.1 document.
+func WriteJSON(w io.Writer, f Feed) error {
+	out := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		Description: f.Description,
+		HomePageURL: f.HomeURL,
+		FeedURL:     f.SelfURL,
+		Items:       []jsonItem{},
+	}
+	for _, p := range f.Posts {
+		out.Items = append(out.Items, jsonItem{
+			ID:            p.URL,
+			URL:           p.URL,
+			Title:         p.Title,
+			ContentText:   p.Title + " (via " + p.Source + ")",
+			DatePublished: f.entryDate(p).Format(time.RFC3339),
+			Authors:       []jsonAuthor{{Name: p.Source}},
+			Tags:          []string{p.Source},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+func sampleFeed() Feed {
+	return Feed{
+		Title:   "GRIP: golang",
+		SelfURL: "http://localhost:8080/feed.xml?q=golang",
+		HomeURL: "http://localhost:8080/?q=golang",
+		Posts: []logic.Post{
+			{Title: "Generics & you", URL: "https://dev.to/a", Source: "Dev.to", PublishedAt: time.Date(2026, 1, 21, 10, 0, 0, 0, time.UTC)},
+			{Title: "No date", URL: "https://lobste.rs/b", Source: "Lobsters"},
+		},
+	}
+}
+
+func TestWriteAtom(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteAtom(&buf, sampleFeed()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got atomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if got.Updated != "2026-01-21T10:00:00Z" {
+		t.Errorf("expected feed updated to be the newest post, got %s", got.Updated)
+	}
+	if len(got.Entries) != 2 || got.Entries[0].ID != "https://dev.to/a" || got.Entries[0].Author.Name != "Dev.to" {
+		t.Errorf("unexpected entries: %+v", got.Entries)
+	}
+	if got.Entries[1].Updated != got.Updated {
+		t.Errorf("expected undated posts to fall back to the feed date, got %s", got.Entries[1].Updated)
+	}
+}
+
+func TestWriteRSS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRSS(&buf, sampleFeed()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{`<rss version="2.0"`, `<guid isPermaLink="true">https://dev.to/a</guid>`, `<category>Lobsters</category>`, `Generics &amp; you`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, sampleFeed()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got jsonFeed
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if got.Version != "https://jsonfeed.org/version/1.1" || got.FeedURL == "" {
+		t.Errorf("unexpected feed header: %+v", got)
+	}
+	if len(got.Items) != 2 || got.Items[0].Authors[0].Name != "Dev.to" {
+		t.Errorf("unexpected items: %+v", got.Items)
+	}
+}
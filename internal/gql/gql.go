@@ -0,0 +1,191 @@
+// Package gql exposes the GRIP engine over GraphQL. The schema lives in
+// schema.graphql and is resolved directly against logic.Engine.
+package gql
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+//go:embed schema.graphql
+var Schema string
+
+// NewSchema parses the GRIP schema with a resolver bound to engine.
+func NewSchema(engine *logic.Engine) (*graphql.Schema, error) {
+	return graphql.ParseSchema(Schema, &Resolver{Engine: engine})
+}
+
+// NewHandler serves GraphQL POST requests against engine.
+func NewHandler(engine *logic.Engine) (http.Handler, error) {
+	schema, err := NewSchema(engine)
+	if err != nil {
+		return nil, err
+	}
+	return &relay.Handler{Schema: schema}, nil
+}
+
+// Resolver is the root Query resolver.
+type Resolver struct {
+	Engine *logic.Engine
+}
+
+// SearchArgs mirrors the arguments of Query.search. Arguments with a schema
+// default are plain values; the rest are nil when omitted.
+type SearchArgs struct {
+	Query   string
+	Sources *[]string
+	Since   *graphql.Time
+	Limit   int32
+	After   *string
+}
+
+// Search resolves Query.search.
+func (r *Resolver) Search(ctx context.Context, args SearchArgs) (*SearchResultResolver, error) {
+	opts := logic.SearchOptions{Query: args.Query}
+	if opts.Query == "" {
+		opts.Query = "golang"
+	}
+	if err := logic.ValidateQuery(opts.Query); err != nil {
+		return nil, err
+	}
+	if args.Sources != nil {
+		opts.Sources = *args.Sources
+	}
+	if args.Since != nil {
+		opts.Since = args.Since.Time
+	}
+	if args.Limit < 1 || args.Limit > logic.MaxLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", logic.MaxLimit)
+	}
+	opts.Limit = int(args.Limit)
+	if args.After != nil {
+		opts.After = *args.After
+	}
+
+	start := time.Now()
+	res, err := r.Engine.Search(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResultResolver{query: opts.Query, res: res, latency: time.Since(start)}, nil
+}
+
+// Sources resolves Query.sources.
+func (r *Resolver) Sources() []string {
+	names := make([]string, len(r.Engine.Sources))
+	for i, s := range r.Engine.Sources {
+		names[i] = logic.SourceName(s)
+	}
+	return names
+}
+
+// SearchResultResolver resolves the SearchResult type.
+type SearchResultResolver struct {
+	query   string
+	res     logic.SearchResult
+	latency time.Duration
+}
+
+func (s *SearchResultResolver) Query() string { return s.query }
+
+func (s *SearchResultResolver) LatencyMs() int32 { return int32(s.latency.Milliseconds()) }
+
+func (s *SearchResultResolver) Posts() []*PostResolver {
+	posts := make([]*PostResolver, len(s.res.Posts))
+	for i := range s.res.Posts {
+		posts[i] = &PostResolver{p: s.res.Posts[i]}
+	}
+	return posts
+}
+
+func (s *SearchResultResolver) Sources() []*SourceStatusResolver {
+	statuses := make([]*SourceStatusResolver, len(s.res.Sources))
+	for i := range s.res.Sources {
+		statuses[i] = &SourceStatusResolver{s: s.res.Sources[i]}
+	}
+	return statuses
+}
+
+func (s *SearchResultResolver) PageInfo() *PageInfoResolver {
+	return &PageInfoResolver{cursor: s.res.NextCursor}
+}
+
+// PostResolver resolves the Post type.
+type PostResolver struct {
+	p logic.Post
+}
+
+func (p *PostResolver) Title() string             { return p.p.Title }
+func (p *PostResolver) URL() string               { return p.p.URL }
+func (p *PostResolver) Source() string            { return p.p.Source }
+func (p *PostResolver) PublishedAt() graphql.Time { return graphql.Time{Time: p.p.PublishedAt} }
+
+// Author resolves to null for sources that do not name one.
+func (p *PostResolver) Author() *string {
+	if p.p.Author == "" {
+		return nil
+	}
+	return &p.p.Author
+}
+
+// Score, Comments and DiscussionURL resolve to null for sources that do not report them.
+func (p *PostResolver) Score() *int32 {
+	if p.p.Score == 0 {
+		return nil
+	}
+	n := int32(p.p.Score)
+	return &n
+}
+
+func (p *PostResolver) Comments() *int32 {
+	if p.p.Comments == 0 {
+		return nil
+	}
+	n := int32(p.p.Comments)
+	return &n
+}
+
+func (p *PostResolver) DiscussionURL() *string {
+	if p.p.DiscussionURL == "" {
+		return nil
+	}
+	return &p.p.DiscussionURL
+}
+
+// SourceStatusResolver resolves the SourceStatus type.
+type SourceStatusResolver struct {
+	s logic.SourceStatus
+}
+
+func (s *SourceStatusResolver) Name() string     { return s.s.Name }
+func (s *SourceStatusResolver) Status() string   { return s.s.Status }
+func (s *SourceStatusResolver) Count() int32     { return int32(s.s.Count) }
+func (s *SourceStatusResolver) LatencyMs() int32 { return int32(s.s.LatencyMS) }
+
+func (s *SourceStatusResolver) Error() *string {
+	if s.s.Error == "" {
+		return nil
+	}
+	return &s.s.Error
+}
+
+// PageInfoResolver resolves the PageInfo type.
+type PageInfoResolver struct {
+	cursor string
+}
+
+func (p *PageInfoResolver) HasNextPage() bool { return p.cursor != "" }
+
+func (p *PageInfoResolver) EndCursor() *string {
+	if p.cursor == "" {
+		return nil
+	}
+	return &p.cursor
+}
@@ -0,0 +1,138 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// fakeSource returns canned posts under a fixed name.
+type fakeSource struct {
+	name  string
+	posts []logic.Post
+	err   error
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func (f *fakeSource) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	return f.posts, f.err
+}
+
+func newTestEngine() *logic.Engine {
+	now := time.Date(2026, 1, 21, 10, 0, 0, 0, time.UTC)
+	return logic.NewEngine([]logic.Source{
+		&fakeSource{name: "Fake A", posts: []logic.Post{
+			{Title: "A1", URL: "https://a.example/1", Source: "Fake A", PublishedAt: now},
+			{Title: "A2", URL: "https://a.example/2", Source: "Fake A", PublishedAt: now.Add(-48 * time.Hour)},
+		}},
+		&fakeSource{name: "Fake B", posts: []logic.Post{
+			{Title: "B1", URL: "https://b.example/1", Source: "Fake B", PublishedAt: now.Add(-time.Hour)},
+		}},
+		&fakeSource{name: "Broken", err: errors.New("boom")},
+	})
+}
+
+func exec(t *testing.T, query string, vars map[string]any) (map[string]any, []string) {
+	t.Helper()
+	schema, err := NewSchema(newTestEngine())
+	if err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+
+	resp := schema.Exec(context.Background(), query, "", vars)
+	var errs []string
+	for _, e := range resp.Errors {
+		errs = append(errs, e.Message)
+	}
+
+	var data map[string]any
+	if resp.Data != nil {
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return data, errs
+}
+
+func TestSearchResolver(t *testing.T) {
+	data, errs := exec(t, `{
+		search(query: "go", limit: 2) {
+			query
+			posts { title source publishedAt }
+			sources { name status count error }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`, nil)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	search := data["search"].(map[string]any)
+	posts := search["posts"].([]any)
+	if len(posts) != 2 || posts[0].(map[string]any)["title"] != "A1" || posts[1].(map[string]any)["title"] != "B1" {
+		t.Errorf("unexpected posts: %v", posts)
+	}
+
+	pageInfo := search["pageInfo"].(map[string]any)
+	if pageInfo["hasNextPage"] != true || pageInfo["endCursor"] == nil {
+		t.Errorf("expected another page: %v", pageInfo)
+	}
+
+	sources := search["sources"].([]any)
+	broken := sources[2].(map[string]any)
+	if broken["status"] != "error" || broken["error"] != "boom" {
+		t.Errorf("expected broken source to report its error: %v", broken)
+	}
+}
+
+func TestSearchResolverFilters(t *testing.T) {
+	data, errs := exec(t, `query($since: Time) {
+		search(sources: ["fake-a"], since: $since) { posts { title } sources { name } }
+	}`, map[string]any{"since": "2026-01-20T00:00:00Z"})
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	search := data["search"].(map[string]any)
+	posts := search["posts"].([]any)
+	if len(posts) != 1 || posts[0].(map[string]any)["title"] != "A1" {
+		t.Errorf("expected only the recent Fake A post, got %v", posts)
+	}
+	if len(search["sources"].([]any)) != 1 {
+		t.Errorf("expected only one source to be queried: %v", search["sources"])
+	}
+}
+
+func TestSearchResolverErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"unknown source", `{ search(sources: ["myspace"]) { query } }`},
+		{"bad limit", `{ search(limit: 1000) { query } }`},
+		{"bad cursor", `{ search(after: "%%%") { query } }`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, errs := exec(t, tt.query, nil); len(errs) == 0 {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSourcesResolver(t *testing.T) {
+	data, errs := exec(t, `{ sources }`, nil)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if got := data["sources"].([]any); len(got) != 3 || got[0] != "Fake A" {
+		t.Errorf("unexpected sources: %v", got)
+	}
+}
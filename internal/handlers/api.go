@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// APIVersion is reported in every versioned API response.
+const APIVersion = "v1"
+
+const mimeJSON = "application/json"
+
+// SearchResponse is the envelope returned by the versioned search API.
+type SearchResponse struct {
+	APIVersion string               `json:"api_version" example:"v1"`
+	Query      string               `json:"query" example:"golang"`
+	Results    []logic.Post         `json:"results"`
+	NextCursor string               `json:"next_cursor,omitempty" example:"MTc2ODk5MDQwMDAwMDAwMDAwMHxodHRwczovL2Rldi50by91c2VyL3Bvc3Q"`
+	Sources    []logic.SourceStatus `json:"sources"`
+	LatencyMS  int64                `json:"latency_ms" example:"471"`
+}
+
+// HandleSearchV1 serves one page of search results wrapped in a SearchResponse.
+// @Summary      Search posts (v1)
+// @Description  Returns a page of the newest posts with per-source status and a cursor for the next page.
+// @Description  Pass next_cursor back as 'after' to fetch the following page.
+// @Tags         search
+// @Produce      json
+// @Produce      application/problem+json
+// @Param        q        query     string    false  "Search query (defaults to 'golang')"
+// @Param        limit    query     int       false  "Page size (1-100, defaults to 20)"
+// @Param        after    query     string    false  "Cursor from a previous response's next_cursor"
+// @Param        sources  query     []string  false  "Only query these sources (comma separated or repeated)"  collectionFormat(csv)
+// @Success      200      {object}  SearchResponse
+// @Failure      400      {object}  Problem  "invalid_query or unknown_source"
+// @Failure      401      {object}  Problem  "unauthorized: missing, invalid or revoked API key"
+// @Failure      406      {object}  Problem  "not_acceptable: the Accept header does not allow application/json"
+// @Failure      429      {object}  Problem  "rate_limited"
+// @Failure      502      {object}  Problem  "all_sources_failed"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/search [get]
+func (h *Handler) HandleSearchV1(w http.ResponseWriter, r *http.Request) {
+	if negotiate(r.Header.Get("Accept"), mimeJSON, mimeProblem) == "" {
+		WriteProblem(w, r, http.StatusNotAcceptable, CodeNotAcceptable, "this endpoint only produces "+mimeJSON)
+		return
+	}
+
+	opts, err := parseSearchOptions(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, err.Error())
+		return
+	}
+
+	start := time.Now()
+	res, err := h.Engine.Search(r.Context(), opts)
+	if err != nil {
+		writeSearchError(w, r, err)
+		return
+	}
+	if res.AllFailed() {
+		WriteProblem(w, r, http.StatusBadGateway, CodeAllSourcesFailed, "every source failed or timed out")
+		return
+	}
+
+	resp := SearchResponse{
+		APIVersion: APIVersion,
+		Query:      opts.Query,
+		Results:    res.Posts,
+		NextCursor: res.NextCursor,
+		Sources:    res.Sources,
+		LatencyMS:  time.Since(start).Milliseconds(),
+	}
+
+	w.Header().Add("Vary", "Accept")
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// parseSearchOptions validates the query parameters shared by the search APIs.
+func parseSearchOptions(r *http.Request) (logic.SearchOptions, error) {
+	params := r.URL.Query()
+	opts := logic.SearchOptions{
+		Query: strings.TrimSpace(params.Get("q")),
+		After: params.Get("after"),
+	}
+	if opts.Query == "" {
+		opts.Query = "golang"
+	}
+	if err := logic.ValidateQuery(opts.Query); err != nil {
+		return opts, err
+	}
+
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > logic.MaxLimit {
+			return opts, fmt.Errorf("limit must be a number between 1 and %d", logic.MaxLimit)
+		}
+		opts.Limit = limit
+	}
+
+	for _, v := range params["sources"] {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				opts.Sources = append(opts.Sources, name)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// writeSearchError maps an Engine.Search error onto its problem code.
+func writeSearchError(w http.ResponseWriter, r *http.Request, err error) {
+	var unknown *logic.UnknownSourceError
+	switch {
+	case errors.As(err, &unknown):
+		WriteProblem(w, r, http.StatusBadRequest, CodeUnknownSource, err.Error())
+	case errors.Is(err, logic.ErrInvalidCursor):
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, "after is not a valid cursor")
+	default:
+		WriteProblem(w, r, http.StatusInternalServerError, CodeInternal, err.Error())
+	}
+}
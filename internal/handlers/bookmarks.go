@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+// BookmarkList is the envelope returned when listing bookmarks.
+type BookmarkList struct {
+	APIVersion string           `json:"api_version" example:"v1"`
+	Bookmarks  []store.Bookmark `json:"bookmarks"`
+}
+
+// HandleListBookmarks lists saved posts, most recently saved first.
+// @Summary      List bookmarks
+// @Tags         bookmarks
+// @Produce      json
+// @Success      200  {object}  BookmarkList
+// @Failure      429  {object}  Problem  "rate_limited"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/bookmarks [get]
+func (h *Handler) HandleListBookmarks(w http.ResponseWriter, r *http.Request) {
+	bookmarks, err := h.Store.Bookmarks()
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	if bookmarks == nil {
+		bookmarks = []store.Bookmark{}
+	}
+	writeJSON(w, r, http.StatusOK, BookmarkList{APIVersion: APIVersion, Bookmarks: bookmarks})
+}
+
+// HandleAddBookmark saves a post to read later.
+// @Summary      Bookmark a post
+// @Description  Saving a post that is already bookmarked returns the existing bookmark with 200.
+// @Description  At most 1000 posts can be bookmarked; remove some to save more.
+// @Tags         bookmarks
+// @Accept       json
+// @Produce      json
+// @Param        post  body      logic.Post  true  "Post to save, as returned by the search API"
+// @Success      200   {object}  store.Bookmark  "Already bookmarked"
+// @Success      201   {object}  store.Bookmark
+// @Failure      400   {object}  Problem  "invalid_request"
+// @Failure      401   {object}  Problem  "unauthorized: API keys are enabled and none was sent"
+// @Failure      409   {object}  Problem  "conflict: the bookmark limit is reached"
+// @Failure      429   {object}  Problem  "rate_limited"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/bookmarks [post]
+func (h *Handler) HandleAddBookmark(w http.ResponseWriter, r *http.Request) {
+	p, ok := decodePost(w, r)
+	if !ok {
+		return
+	}
+
+	b, created, err := h.Store.AddBookmark(p)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	w.Header().Set("Location", "/api/v1/bookmarks/"+b.ID)
+	writeJSON(w, r, status, b)
+}
+
+// HandleRemoveBookmark deletes a bookmark.
+// @Summary      Remove a bookmark
+// @Tags         bookmarks
+// @Param        id   path  string  true  "Bookmark ID"
+// @Success      204
+// @Failure      401  {object}  Problem  "unauthorized: API keys are enabled and none was sent"
+// @Failure      404  {object}  Problem  "not_found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/bookmarks/{id} [delete]
+func (h *Handler) HandleRemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	if err := h.Store.RemoveBookmark(r.PathValue("id")); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodePost reads a post from the request body, writing a problem and
+// reporting false when it is malformed.
+func decodePost(w http.ResponseWriter, r *http.Request) (logic.Post, bool) {
+	var p logic.Post
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&p); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "body must be a JSON post: "+err.Error())
+		return p, false
+	}
+	if u, err := url.Parse(p.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "url must be an absolute http(s) URL")
+		return p, false
+	}
+	if p.Title == "" {
+		p.Title = p.URL
+	}
+	return p, true
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Numpkens/grip/internal/feed"
+)
+
+// defaultFeedLimit is the number of entries in a feed that does not set limit.
+const defaultFeedLimit = 50
+
+// HandleFeedXML serves a search as an Atom or RSS feed.
+// @Summary      Search results as Atom or RSS
+// @Description  Renders the newest posts for q as Atom 1.0 by default. Pass format=rss, or prefer
+// @Description  application/rss+xml in the Accept header, to get RSS 2.0 instead.
+// @Tags         feeds
+// @Produce      application/atom+xml
+// @Produce      application/rss+xml
+// @Param        q        query     string    false  "Search query (defaults to 'golang')"
+// @Param        format   query     string    false  "Feed format"  Enums(atom, rss)
+// @Param        limit    query     int       false  "Number of entries (1-100, defaults to 50)"
+// @Param        sources  query     []string  false  "Only query these sources (comma separated or repeated)"  collectionFormat(csv)
+// @Success      200      {string}  string  "Feed document"
+// @Failure      400      {object}  Problem  "invalid_query or unknown_source"
+// @Router       /feed.xml [get]
+func (h *Handler) HandleFeedXML(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" && negotiate(r.Header.Get("Accept"), feed.MIMEAtom, feed.MIMERSS) == feed.MIMERSS {
+		format = "rss"
+	}
+
+	switch format {
+	case "", "atom":
+		h.serveFeed(w, r, feed.MIMEAtom, feed.WriteAtom)
+	case "rss":
+		h.serveFeed(w, r, feed.MIMERSS, feed.WriteRSS)
+	default:
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, "format must be atom or rss")
+	}
+}
+
+// HandleFeedJSON serves a search as a JSON Feed.
+// @Summary      Search results as JSON Feed
+// @Description  Renders the newest posts for q as a JSON Feed 1.1 document.
+// @Tags         feeds
+// @Produce      application/feed+json
+// @Param        q        query     string    false  "Search query (defaults to 'golang')"
+// @Param        limit    query     int       false  "Number of entries (1-100, defaults to 50)"
+// @Param        sources  query     []string  false  "Only query these sources (comma separated or repeated)"  collectionFormat(csv)
+// @Success      200      {string}  string  "Feed document"
+// @Failure      400      {object}  Problem  "invalid_query or unknown_source"
+// @Router       /feed.json [get]
+func (h *Handler) HandleFeedJSON(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, feed.MIMEJSON, feed.WriteJSON)
+}
+
+// serveFeed runs the search for q and renders it with write.
+func (h *Handler) serveFeed(w http.ResponseWriter, r *http.Request, contentType string, write func(io.Writer, feed.Feed) error) {
+	opts, err := parseSearchOptions(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, err.Error())
+		return
+	}
+	if opts.Limit == 0 {
+		opts.Limit = defaultFeedLimit
+	}
+
+	res, err := h.Engine.Search(r.Context(), opts)
+	if err != nil {
+		writeSearchError(w, r, err)
+		return
+	}
+	f := feed.Feed{
+		Title:       "GRIP: " + opts.Query,
+		Description: "The newest developer posts about " + opts.Query + ", aggregated by GRIP.",
+		SelfURL:     h.absoluteURL(r, r.URL.RequestURI()),
+		HomeURL:     h.absoluteURL(r, "/?q="+url.QueryEscape(opts.Query)),
+		Posts:       res.Posts,
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf, f); err != nil {
+		log.Printf("Feed rendering error: %v", err)
+		WriteProblem(w, r, http.StatusInternalServerError, CodeInternal, "failed to render feed")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.Header().Add("Vary", "Accept")
+	w.Write(buf.Bytes())
+}
+
+// absoluteURL resolves path against the host the request was made to. With
+// TrustProxy set, the scheme and host the proxy reports take precedence.
+func (h *Handler) absoluteURL(r *http.Request, path string) string {
+	scheme, host := "http", r.Host
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if h.TrustProxy {
+		if proto := forwarded(r, "X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if fwd := forwarded(r, "X-Forwarded-Host"); fwd != "" {
+			host = fwd
+		}
+	}
+	return scheme + "://" + host + path
+}
+
+// forwarded returns the first value of a proxy header, which was set by the
+// proxy closest to the client.
+func forwarded(r *http.Request, header string) string {
+	first, _, _ := strings.Cut(r.Header.Get(header), ",")
+	return strings.ToLower(strings.TrimSpace(first))
+}
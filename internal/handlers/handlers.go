@@ -3,37 +3,72 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
 	"html/template"
+	"log"
 	"net/http"
 	"time"
-	"log"
 )
 
 // Handler maintains the dependencies required to serve GRIP requests.
+// A nil Templ means the HTML UI is disabled and HandleHome always answers with JSON.
+// Store backs the saved-search, bookmark and history routes and the UI's bookmarks
+// and read marks. It is nil when those routes are not mounted.
 type Handler struct {
 	Templ  *template.Template
 	Engine *logic.Engine
+	Store  *store.Store
+	// TrustProxy builds absolute links from X-Forwarded-Proto and
+	// X-Forwarded-Host, mirroring server.trust_proxy.
+	TrustProxy bool
 }
+
 // TemplateData sends server performance information for the template to consume and display
 type TemplateData struct {
-	Results []logic.Post
+	Results []Card
 	Query   string
 	Latency string
+	// View is "saved" or "history" when the page lists bookmarks or opened
+	// posts instead of search results.
+	View string
+	// Personal enables bookmarks and read tracking, which need the server store.
+	Personal bool
+	// HideRead reports that opened posts were filtered out of the results.
+	HideRead bool
+}
+
+// Card is a post as rendered by the HTML template.
+type Card struct {
+	logic.Post
+	BookmarkID string
+	Saved      bool
+	Read       bool
+}
+
+// HealthStatus is the body returned by the health check endpoints.
+type HealthStatus struct {
+	Status  string `json:"status" example:"ok"`
+	Sources int    `json:"sources,omitempty" example:"6"`
 }
 
 // HandleHome aggregates and serves blog posts via HTML or JSON.
 // @Summary      Search Aggregated Blogs
-// @Description  Returns the top 20 newest posts. 
-// @Description  IMPORTANT: You must set the 'Accept: application/json' header to receive JSON. 
-// @Description  Without this header, the server will default to serving the HTML template.
+// @Description  Returns the top 20 newest posts.
+// @Description  The response format is negotiated from the Accept header: JSON is served when
+// @Description  application/json is preferred over text/html, otherwise the HTML template is rendered.
+// @Description  New integrations should use /api/v1/search instead.
+// @Tags         ui
 // @Produce      json
 // @Produce      html
-// @Param        q    query     string  false  "Search Keyword (defaults to 'golang')"
+// @Param        q     query     string  false  "Search Keyword (defaults to 'golang')"
+// @Param        view       query     string  false  "Show bookmarks or reading history instead of search results"  Enums(saved, history)
+// @Param        hide_read  query     bool    false  "Leave out posts that have already been opened"
 // @Success      200  {array}   logic.Post "Successfully retrieved posts"
 // @Failure      404  {string}  string     "Not Found: Only the root path '/' is supported"
-// @Failure      500  {string}  string     "Internal Server Error"
+// @Failure      500  {object}  Problem    "Internal Server Error"
 // @Router       / [get]
 func (h *Handler) HandleHome(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path != "/" {
@@ -44,26 +79,146 @@ func (h *Handler) HandleHome(w http.ResponseWriter, r *http.Request) {
 	if query == "" {
 		query = "golang"
 	}
+	view := r.URL.Query().Get("view")
+	hideRead := r.URL.Query().Get("hide_read") != "" && h.Store != nil
 
 	start := time.Now()
-	posts := h.Engine.Collect(r.Context(), query)
+	var posts []logic.Post
+	switch {
+	case view == "saved" && h.Store != nil:
+		bookmarks, err := h.Store.Bookmarks()
+		if err != nil {
+			log.Printf("Bookmarks error: %v", err)
+		}
+		for _, b := range bookmarks {
+			posts = append(posts, b.Post)
+		}
+	case view == "history" && h.Store != nil:
+		visits, err := h.Store.History(0)
+		if err != nil {
+			log.Printf("History error: %v", err)
+		}
+		for _, v := range visits {
+			posts = append(posts, v.Post)
+		}
+		hideRead = false
+	default:
+		view = ""
+		posts = h.Engine.Collect(r.Context(), query)
+	}
 	latency := time.Since(start).Truncate(time.Millisecond).String()
+	cards := h.cards(posts, hideRead)
 
-	if r.Header.Get("Accept") == "application/json" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(posts)
+	w.Header().Add("Vary", "Accept")
+	if h.Templ == nil || negotiate(r.Header.Get("Accept"), "text/html", mimeJSON) == mimeJSON {
+		posts = []logic.Post{}
+		for _, c := range cards {
+			posts = append(posts, c.Post)
+		}
+		writeJSON(w, r, http.StatusOK, posts)
 		return
 	}
 
 	data := TemplateData{
-		Results: posts,
-		Query:   query,
-		Latency: latency,
+		Results:  cards,
+		Query:    query,
+		Latency:  latency,
+		View:     view,
+		Personal: h.Store != nil,
+		HideRead: hideRead,
 	}
-	
-	err := h.Templ.Execute(w, data)
-	if err != nil {
+
+	// Render into a buffer so a template failure becomes a clean 500 instead of a truncated page.
+	var buf bytes.Buffer
+	if err := h.Templ.Execute(&buf, data); err != nil {
 		log.Printf("Template execution error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
-}
\ No newline at end of file
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// cards annotates posts with their bookmark and read state for the
+// template, dropping read posts when hideRead is set.
+func (h *Handler) cards(posts []logic.Post, hideRead bool) []Card {
+	saved, read := map[string]bool{}, map[string]bool{}
+	if h.Store != nil {
+		var err error
+		if saved, err = h.Store.BookmarkedURLs(); err != nil {
+			log.Printf("Bookmarks error: %v", err)
+		}
+		if read, err = h.Store.ReadURLs(); err != nil {
+			log.Printf("History error: %v", err)
+		}
+	}
+
+	cards := make([]Card, 0, len(posts))
+	for _, p := range posts {
+		if hideRead && read[p.URL] {
+			continue
+		}
+		cards = append(cards, Card{Post: p, BookmarkID: store.BookmarkID(p.URL), Saved: saved[p.URL], Read: read[p.URL]})
+	}
+	return cards
+}
+
+// HandleSearch serves raw search results as JSON.
+// @Summary      Search posts
+// @Description  Returns raw search results as JSON
+// @Tags         search
+// @Produce      json
+// @Param        q        query     string    false  "Search query (defaults to 'golang')"
+// @Param        limit    query     int       false  "Number of posts (1-100, defaults to 20)"
+// @Param        sources  query     []string  false  "Only query these sources (comma separated or repeated)"  collectionFormat(csv)
+// @Success      200      {array}   logic.Post
+// @Failure      400      {object}  Problem  "invalid_query or unknown_source"
+// @Failure      429      {object}  Problem  "rate_limited"
+// @Failure      500      {object}  Problem  "internal_error"
+// @Router       /api/search [get]
+func (h *Handler) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseSearchOptions(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, err.Error())
+		return
+	}
+
+	res, err := h.Engine.Search(r.Context(), opts)
+	if err != nil {
+		writeSearchError(w, r, err)
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, res.Posts)
+}
+
+// HandleHealth reports that the process is up.
+// @Summary      Liveness check
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  HealthStatus
+// @Router       /healthz [get]
+func (h *Handler) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(HealthStatus{Status: "ok"})
+}
+
+// HandleReady reports whether the engine has any sources to query.
+// @Summary      Readiness check
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  HealthStatus
+// @Failure      503  {object}  HealthStatus
+// @Router       /readyz [get]
+func (h *Handler) HandleReady(w http.ResponseWriter, r *http.Request) {
+	status := HealthStatus{Status: "ok", Sources: len(h.Engine.Sources)}
+	code := http.StatusOK
+	if status.Sources == 0 {
+		status.Status = "no sources configured"
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+}
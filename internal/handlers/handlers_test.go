@@ -1,10 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
 )
 
 func TestHandleHome_JSON(t *testing.T) {
@@ -29,3 +35,279 @@ func TestHandleHome_JSON(t *testing.T) {
 		t.Errorf("handler returned wrong content type: got %v want %v", contentType, expectedContentType)
 	}
 }
+
+func TestHandleHome_NegotiatesJSONWithWildcard(t *testing.T) {
+	h := &Handler{
+		Engine: &logic.Engine{Sources: []logic.Source{}},
+	}
+
+	req := httptest.NewRequest("GET", "/?q=golang", nil)
+	req.Header.Set("Accept", "application/json, */*")
+	rr := httptest.NewRecorder()
+	h.HandleHome(rr, req)
+
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected JSON for 'application/json, */*', got %v", contentType)
+	}
+}
+
+func TestHandleSearchV1_Envelope(t *testing.T) {
+	h := &Handler{
+		Engine: &logic.Engine{Sources: []logic.Source{}},
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/search?q=rust&limit=5", nil)
+	rr := httptest.NewRecorder()
+	h.HandleSearchV1(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp SearchResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if resp.APIVersion != APIVersion || resp.Query != "rust" {
+		t.Errorf("unexpected envelope: %+v", resp)
+	}
+	if resp.Results == nil || resp.Sources == nil {
+		t.Errorf("expected empty arrays rather than null: %+v", resp)
+	}
+}
+
+func TestHandleSearchV1_BadRequests(t *testing.T) {
+	h := &Handler{
+		Engine: &logic.Engine{Sources: []logic.Source{}},
+	}
+
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		want   int
+	}{
+		{"limit too large", "/api/v1/search?limit=500", "", http.StatusBadRequest},
+		{"garbage cursor", "/api/v1/search?after=%25%25", "", http.StatusBadRequest},
+		{"html only", "/api/v1/search", "text/html", http.StatusNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rr := httptest.NewRecorder()
+			h.HandleSearchV1(rr, req)
+			if rr.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, rr.Code)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("expected problem+json, got %v", ct)
+			}
+		})
+	}
+}
+
+func TestHandleSearch_Options(t *testing.T) {
+	now := time.Now()
+	h := &Handler{
+		Engine: &logic.Engine{Sources: []logic.Source{staticSource{
+			{Title: "golang one", URL: "https://example.com/1", PublishedAt: now},
+			{Title: "golang two", URL: "https://example.com/2", PublishedAt: now.Add(-time.Hour)},
+		}}},
+	}
+
+	tests := []struct {
+		url   string
+		want  int
+		posts int
+	}{
+		{"/api/search?q=golang", http.StatusOK, 2},
+		{"/api/search?q=golang&limit=1", http.StatusOK, 1},
+		{"/api/search?q=golang&sources=myspace", http.StatusBadRequest, 0},
+		{"/api/search?q=golang&limit=500", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		h.HandleSearch(rr, httptest.NewRequest("GET", tt.url, nil))
+		if rr.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.url, tt.want, rr.Code, rr.Body.String())
+			continue
+		}
+		if tt.want != http.StatusOK {
+			continue
+		}
+		var posts []logic.Post
+		if err := json.NewDecoder(rr.Body).Decode(&posts); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", tt.url, err)
+		}
+		if len(posts) != tt.posts {
+			t.Errorf("%s: expected %d posts, got %d", tt.url, tt.posts, len(posts))
+		}
+	}
+}
+
+type failingSource struct{}
+
+func (s *failingSource) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	return nil, errors.New("upstream down")
+}
+
+func TestHandleSearchV1_ProblemCodes(t *testing.T) {
+	h := &Handler{
+		Engine: &logic.Engine{Sources: []logic.Source{&failingSource{}}},
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+		code string
+	}{
+		{"unknown source", "/api/v1/search?sources=myspace", http.StatusBadRequest, CodeUnknownSource},
+		{"all sources failed", "/api/v1/search?q=go", http.StatusBadGateway, CodeAllSourcesFailed},
+		{"control characters", "/api/v1/search?q=go%00", http.StatusBadRequest, CodeInvalidQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			h.HandleSearchV1(rr, httptest.NewRequest("GET", tt.url, nil))
+
+			var p Problem
+			if err := json.NewDecoder(rr.Body).Decode(&p); err != nil {
+				t.Fatalf("invalid problem body: %v", err)
+			}
+			if rr.Code != tt.want || p.Status != tt.want || p.Code != tt.code {
+				t.Errorf("expected %d/%s, got %d/%+v", tt.want, tt.code, rr.Code, p)
+			}
+		})
+	}
+}
+
+func TestHandleFeedXML_Formats(t *testing.T) {
+	h := &Handler{
+		Engine: &logic.Engine{Sources: []logic.Source{}},
+	}
+
+	tests := []struct {
+		url    string
+		accept string
+		want   string
+	}{
+		{"/feed.xml?q=go", "", "application/atom+xml; charset=utf-8"},
+		{"/feed.xml?q=go&format=rss", "", "application/rss+xml; charset=utf-8"},
+		{"/feed.xml?q=go", "application/rss+xml", "application/rss+xml; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rr := httptest.NewRecorder()
+		h.HandleFeedXML(rr, req)
+
+		if ct := rr.Header().Get("Content-Type"); ct != tt.want {
+			t.Errorf("%s (Accept %q): expected %s, got %s", tt.url, tt.accept, tt.want, ct)
+		}
+	}
+}
+
+func TestHandleFeedJSON_OptionsAndProxy(t *testing.T) {
+	now := time.Now()
+	posts := staticSource{
+		{Title: "golang one", URL: "https://example.com/1", PublishedAt: now},
+		{Title: "golang two", URL: "https://example.com/2", PublishedAt: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name       string
+		trustProxy bool
+		url        string
+		want       int
+		items      int
+		feedURL    string
+	}{
+		{"defaults", false, "/feed.json?q=golang", http.StatusOK, 2, "http://grip.example/feed.json?q=golang"},
+		{"limit", false, "/feed.json?q=golang&limit=1", http.StatusOK, 1, "http://grip.example/feed.json?q=golang&limit=1"},
+		{"proxy ignored", false, "/feed.json?q=go", http.StatusOK, 2, "http://grip.example/feed.json?q=go"},
+		{"proxy trusted", true, "/feed.json?q=go", http.StatusOK, 2, "https://public.example/feed.json?q=go"},
+		{"unknown source", false, "/feed.json?sources=myspace", http.StatusBadRequest, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{Engine: &logic.Engine{Sources: []logic.Source{posts}}, TrustProxy: tt.trustProxy}
+			req := httptest.NewRequest("GET", "http://grip.example"+tt.url, nil)
+			req.Header.Set("X-Forwarded-Proto", "https")
+			req.Header.Set("X-Forwarded-Host", "public.example")
+			rr := httptest.NewRecorder()
+			h.HandleFeedJSON(rr, req)
+			if rr.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rr.Code, rr.Body.String())
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var f struct {
+				FeedURL string            `json:"feed_url"`
+				Items   []json.RawMessage `json:"items"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&f); err != nil {
+				t.Fatalf("invalid feed: %v", err)
+			}
+			if f.FeedURL != tt.feedURL || len(f.Items) != tt.items {
+				t.Errorf("expected %d items at %s, got %d at %s", tt.items, tt.feedURL, len(f.Items), f.FeedURL)
+			}
+		})
+	}
+}
+
+type staticSource []logic.Post
+
+func (s staticSource) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	return s, nil
+}
+
+func TestHandleHome_HideRead(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	read := logic.Post{Title: "golang read", URL: "https://example.com/read", PublishedAt: now}
+	unread := logic.Post{Title: "golang unread", URL: "https://example.com/unread", PublishedAt: now.Add(-time.Hour)}
+	if _, err := st.RecordVisit(read, now); err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{
+		Engine: &logic.Engine{Sources: []logic.Source{staticSource{read, unread}}},
+		Store:  st,
+	}
+
+	for query, want := range map[string]int{"/?q=golang": 2, "/?q=golang&hide_read=1": 1, "/?view=history": 1, "/?view=saved": 0} {
+		req := httptest.NewRequest("GET", query, nil)
+		req.Header.Set("Accept", "application/json")
+		rr := httptest.NewRecorder()
+		h.HandleHome(rr, req)
+		if want == 0 && rr.Body.String() == "null\n" {
+			t.Errorf("%s: expected an empty array, got null", query)
+		}
+
+		var posts []logic.Post
+		if err := json.NewDecoder(rr.Body).Decode(&posts); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", query, err)
+		}
+		if len(posts) != want {
+			t.Errorf("%s: expected %d posts, got %+v", query, want, posts)
+		}
+		if query == "/?q=golang&hide_read=1" && len(posts) == 1 && posts[0].URL != unread.URL {
+			t.Errorf("expected only the unread post, got %+v", posts)
+		}
+	}
+}
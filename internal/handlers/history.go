@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Numpkens/grip/internal/store"
+)
+
+// maxHistoryPage caps one page of reading history.
+const maxHistoryPage = 500
+
+// HistoryList is the envelope returned when listing the reading history.
+type HistoryList struct {
+	APIVersion string        `json:"api_version" example:"v1"`
+	Visits     []store.Visit `json:"visits"`
+}
+
+// HandleListHistory lists opened posts, most recently opened first.
+// @Summary      List reading history
+// @Tags         history
+// @Produce      json
+// @Param        limit  query     int  false  "Number of visits (1-500, defaults to 50)"
+// @Success      200    {object}  HistoryList
+// @Failure      400    {object}  Problem  "invalid_query"
+// @Failure      429    {object}  Problem  "rate_limited"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/history [get]
+func (h *Handler) HandleListHistory(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxHistoryPage {
+			WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, "limit must be a number between 1 and "+strconv.Itoa(maxHistoryPage))
+			return
+		}
+		limit = n
+	}
+
+	visits, err := h.Store.History(limit)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	if visits == nil {
+		visits = []store.Visit{}
+	}
+	writeJSON(w, r, http.StatusOK, HistoryList{APIVersion: APIVersion, Visits: visits})
+}
+
+// HandleRecordVisit marks a post as read.
+// @Summary      Record an opened post
+// @Description  Clients call this when the user opens a post, so later results can be marked as read.
+// @Tags         history
+// @Accept       json
+// @Produce      json
+// @Param        post  body      logic.Post  true  "Opened post, as returned by the search API"
+// @Success      200   {object}  store.Visit
+// @Failure      400   {object}  Problem  "invalid_request"
+// @Failure      401   {object}  Problem  "unauthorized: API keys are enabled and none was sent"
+// @Failure      429   {object}  Problem  "rate_limited"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/history [post]
+func (h *Handler) HandleRecordVisit(w http.ResponseWriter, r *http.Request) {
+	p, ok := decodePost(w, r)
+	if !ok {
+		return
+	}
+
+	v, err := h.Store.RecordVisit(p, time.Now())
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	writeJSON(w, r, http.StatusOK, v)
+}
+
+// HandleClearHistory forgets every opened post.
+// @Summary      Clear reading history
+// @Tags         history
+// @Success      204
+// @Failure      401  {object}  Problem  "unauthorized: API keys are enabled and none was sent"
+// @Failure      429  {object}  Problem  "rate_limited"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/history [delete]
+func (h *Handler) HandleClearHistory(w http.ResponseWriter, r *http.Request) {
+	if err := h.Store.ClearHistory(); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// negotiate picks the offer that best matches the request's Accept header,
+// honouring q-values and preferring more specific media ranges on a tie.
+// A missing header accepts the first offer; no acceptable offer yields "".
+func negotiate(accept string, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	best, bestQ, bestSpec := "", 0.0, -1
+	for _, offer := range offers {
+		q, spec := matchOffer(accept, offer)
+		if q > bestQ || (q == bestQ && q > 0 && spec > bestSpec) {
+			best, bestQ, bestSpec = offer, q, spec
+		}
+	}
+	return best
+}
+
+// matchOffer returns the q-value of the most specific range in accept that
+// covers offer, and how specific that range was (0 for */*, 2 for type/subtype).
+func matchOffer(accept, offer string) (float64, int) {
+	offerType, offerSub, _ := strings.Cut(offer, "/")
+
+	q, spec := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		rangeType, rangeSub, _ := strings.Cut(mediaType, "/")
+
+		s := -1
+		switch {
+		case rangeType == "*" && rangeSub == "*":
+			s = 0
+		case rangeType == offerType && rangeSub == "*":
+			s = 1
+		case rangeType == offerType && rangeSub == offerSub:
+			s = 2
+		}
+		if s <= spec {
+			continue
+		}
+
+		rangeQ := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				rangeQ = parsed
+			}
+		}
+		q, spec = rangeQ, s
+	}
+	return q, spec
+}
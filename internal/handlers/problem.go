@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+const mimeProblem = "application/problem+json"
+
+// problemDocs is the base URI for Problem.Type; each code has an anchor in docs/errors.md.
+const problemDocs = "https://github.com/Numpkens/grip/blob/main/docs/errors.md#"
+
+// Machine-readable error codes carried in Problem.Code.
+const (
+	CodeInvalidQuery     = "invalid_query"
+	CodeInvalidRequest   = "invalid_request"
+	CodeUnknownSource    = "unknown_source"
+	CodeAllSourcesFailed = "all_sources_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeRateLimited      = "rate_limited"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeNotAcceptable    = "not_acceptable"
+	CodeInternal         = "internal_error"
+)
+
+// Problem is an RFC 7807 problem details object returned by every API error.
+type Problem struct {
+	Type     string `json:"type" example:"https://github.com/Numpkens/grip/blob/main/docs/errors.md#invalid_query"`
+	Title    string `json:"title" example:"Bad Request"`
+	Status   int    `json:"status" example:"400"`
+	Detail   string `json:"detail,omitempty" example:"limit must be a number between 1 and 100"`
+	Instance string `json:"instance,omitempty" example:"/api/v1/search?limit=500"`
+	Code     string `json:"code" example:"invalid_query" enums:"invalid_query,invalid_request,unknown_source,all_sources_failed,unauthorized,rate_limited,not_found,conflict,method_not_allowed,not_acceptable,internal_error"`
+}
+
+// WriteProblem sends a problem+json response for status with the given code and detail.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	p := Problem{
+		Type:     problemDocs + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.RequestURI(),
+		Code:     code,
+	}
+
+	w.Header().Set("Content-Type", mimeProblem)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// writeJSON encodes v before touching the response so an encoding failure can
+// still be reported as a 500 problem instead of a half-written body.
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		log.Printf("JSON encoding error: %v", err)
+		WriteProblem(w, r, http.StatusInternalServerError, CodeInternal, "failed to encode response")
+		return
+	}
+
+	w.Header().Set("Content-Type", mimeJSON)
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
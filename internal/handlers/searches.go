@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+// maxBodyBytes caps request bodies on the write endpoints.
+const maxBodyBytes = 64 << 10
+
+// SavedSearchRequest is the body accepted when creating a saved search.
+type SavedSearchRequest struct {
+	Name    string   `json:"name" example:"golang"`
+	Query   string   `json:"query" example:"golang"`
+	Sources []string `json:"sources,omitempty" example:"Dev.to,Lobsters"`
+}
+
+// SavedSearchList is the envelope returned when listing saved searches.
+type SavedSearchList struct {
+	APIVersion string              `json:"api_version" example:"v1"`
+	Searches   []store.SavedSearch `json:"searches"`
+}
+
+// MatchList is the envelope returned for the new posts of a saved search.
+type MatchList struct {
+	APIVersion string        `json:"api_version" example:"v1"`
+	Search     string        `json:"search" example:"golang"`
+	Matches    []store.Match `json:"matches"`
+}
+
+// HandleListSearches lists every saved search.
+// @Summary      List saved searches
+// @Tags         searches
+// @Produce      json
+// @Success      200  {object}  SavedSearchList
+// @Failure      429  {object}  Problem  "rate_limited"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/searches [get]
+func (h *Handler) HandleListSearches(w http.ResponseWriter, r *http.Request) {
+	searches, err := h.Store.Searches()
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	if searches == nil {
+		searches = []store.SavedSearch{}
+	}
+	writeJSON(w, r, http.StatusOK, SavedSearchList{APIVersion: APIVersion, Searches: searches})
+}
+
+// HandleCreateSearch saves a named query for background polling.
+// @Summary      Create a saved search
+// @Description  The scheduler polls saved searches on an interval. The first poll records a baseline,
+// @Description  and every later poll flags posts the search has not seen before.
+// @Tags         searches
+// @Accept       json
+// @Produce      json
+// @Param        search  body      SavedSearchRequest  true  "Saved search"
+// @Success      201     {object}  store.SavedSearch
+// @Failure      400     {object}  Problem  "invalid_request, invalid_query or unknown_source"
+// @Failure      401     {object}  Problem  "unauthorized: API keys are enabled and none was sent"
+// @Failure      409     {object}  Problem  "conflict: a saved search with this name exists"
+// @Failure      429     {object}  Problem  "rate_limited"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/searches [post]
+func (h *Handler) HandleCreateSearch(w http.ResponseWriter, r *http.Request) {
+	var req SavedSearchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "body must be a JSON saved search: "+err.Error())
+		return
+	}
+
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, "query is required")
+		return
+	}
+	if err := logic.ValidateQuery(req.Query); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, err.Error())
+		return
+	}
+	if err := h.Engine.CheckSources(req.Sources); err != nil {
+		writeSearchError(w, r, err)
+		return
+	}
+
+	ss, err := h.Store.AddSearch(store.SavedSearch{Name: req.Name, Query: req.Query, Sources: req.Sources})
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/searches/"+url.PathEscape(ss.Name))
+	writeJSON(w, r, http.StatusCreated, ss)
+}
+
+// HandleGetSearch returns a single saved search.
+// @Summary      Get a saved search
+// @Tags         searches
+// @Produce      json
+// @Param        name  path      string  true  "Saved search name"
+// @Success      200   {object}  store.SavedSearch
+// @Failure      404   {object}  Problem  "not_found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/searches/{name} [get]
+func (h *Handler) HandleGetSearch(w http.ResponseWriter, r *http.Request) {
+	ss, err := h.Store.Search(r.PathValue("name"))
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	writeJSON(w, r, http.StatusOK, ss)
+}
+
+// HandleDeleteSearch removes a saved search and its polling history.
+// @Summary      Delete a saved search
+// @Tags         searches
+// @Param        name  path  string  true  "Saved search name"
+// @Success      204
+// @Failure      401  {object}  Problem  "unauthorized: API keys are enabled and none was sent"
+// @Failure      404  {object}  Problem  "not_found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/searches/{name} [delete]
+func (h *Handler) HandleDeleteSearch(w http.ResponseWriter, r *http.Request) {
+	if err := h.Store.DeleteSearch(r.PathValue("name")); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleSearchMatches lists the posts a saved search has flagged as new.
+// @Summary      New posts for a saved search
+// @Description  Returns the posts background polling found for the first time, newest first.
+// @Tags         searches
+// @Produce      json
+// @Param        name   path      string  true   "Saved search name"
+// @Param        since  query     string  false  "Only matches found after this RFC 3339 time"
+// @Success      200    {object}  MatchList
+// @Failure      400    {object}  Problem  "invalid_query: since is not an RFC 3339 time"
+// @Failure      404    {object}  Problem  "not_found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/searches/{name}/new [get]
+func (h *Handler) HandleSearchMatches(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if v := r.URL.Query().Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			WriteProblem(w, r, http.StatusBadRequest, CodeInvalidQuery, "since must be an RFC 3339 time")
+			return
+		}
+		since = t
+	}
+
+	name := r.PathValue("name")
+	matches, err := h.Store.Matches(name, since)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	if matches == nil {
+		matches = []store.Match{}
+	}
+	writeJSON(w, r, http.StatusOK, MatchList{APIVersion: APIVersion, Search: name, Matches: matches})
+}
+
+// writeStoreError maps a store error onto its problem code.
+func writeStoreError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		WriteProblem(w, r, http.StatusNotFound, CodeNotFound, err.Error())
+	case errors.Is(err, store.ErrExists), errors.Is(err, store.ErrFull):
+		WriteProblem(w, r, http.StatusConflict, CodeConflict, err.Error())
+	case errors.Is(err, store.ErrInvalidName):
+		WriteProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+	default:
+		log.Printf("Store error: %v", err)
+		WriteProblem(w, r, http.StatusInternalServerError, CodeInternal, "failed to access saved data")
+	}
+}
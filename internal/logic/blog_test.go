@@ -24,7 +24,7 @@ func TestFetchAllSorting(t *testing.T) {
 	s2 := &TestSource{Posts: []Post{{Title: "New Post", PublishedAt: now}}}
 
 	engine := &Engine{Sources: []Source{s1, s2}}
-	results := engine.FetchAll("test")
+	results := engine.Collect(context.Background(), "test")
 
 	if len(results) != 2 {
 		t.Fatalf("expected 2 results, got %d", len(results))
@@ -101,7 +101,7 @@ func TestFetchAllTruncation(t *testing.T) {
 
 	s1 := &TestSource{Posts: manyPosts}
 	engine := &Engine{Sources: []Source{s1}}
-	results := engine.FetchAll("test")
+	results := engine.Collect(context.Background(), "test")
 
 	if len(results) != 20 {
 		t.Errorf("Truncation failed: expected 20 results, got %d", len(results))
@@ -114,6 +114,70 @@ func BenchmarkFetchAll(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		engine.FetchAll("test")
+		engine.Collect(context.Background(), "test")
+	}
+}
+
+type failingSource struct{}
+
+func (s *failingSource) Search(ctx context.Context, query string) ([]Post, error) {
+	return nil, fmt.Errorf("upstream down")
+}
+
+func TestSearchPagination(t *testing.T) {
+	var manyPosts []Post
+	base := time.Now()
+	for i := 0; i < 25; i++ {
+		manyPosts = append(manyPosts, Post{
+			Title:       fmt.Sprintf("Post %d", i),
+			URL:         fmt.Sprintf("https://example.com/%d", i),
+			PublishedAt: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	engine := &Engine{Sources: []Source{&TestSource{Posts: manyPosts}}}
+	first, err := engine.Search(context.Background(), SearchOptions{Query: "test", Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.Posts) != 10 || first.Posts[0].Title != "Post 24" {
+		t.Fatalf("unexpected first page: %d posts, first %q", len(first.Posts), first.Posts[0].Title)
+	}
+	if first.NextCursor == "" {
+		t.Fatal("expected a cursor for the next page")
+	}
+
+	second, err := engine.Search(context.Background(), SearchOptions{Query: "test", Limit: 20, After: first.NextCursor})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Posts) != 15 || second.Posts[0].Title != "Post 14" {
+		t.Errorf("unexpected second page: %d posts", len(second.Posts))
+	}
+	if second.NextCursor != "" {
+		t.Errorf("expected no cursor on the last page, got %q", second.NextCursor)
+	}
+
+	if _, err := engine.Search(context.Background(), SearchOptions{After: "%%%"}); err != ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestSearchSourceStatus(t *testing.T) {
+	ok := &TestSource{Posts: []Post{{Title: "A", PublishedAt: time.Now()}}}
+	engine := &Engine{Sources: []Source{ok, &failingSource{}}}
+
+	res, _ := engine.Search(context.Background(), SearchOptions{Query: "test"})
+	if len(res.Sources) != 2 {
+		t.Fatalf("expected 2 statuses, got %d", len(res.Sources))
+	}
+	if res.Sources[0].Status != StatusOK || res.Sources[0].Count != 1 {
+		t.Errorf("unexpected status for working source: %+v", res.Sources[0])
+	}
+	if res.Sources[1].Status != StatusError || res.Sources[1].Error != "upstream down" {
+		t.Errorf("unexpected status for failing source: %+v", res.Sources[1])
+	}
+	if res.Sources[1].Name != "logic.failingSource" {
+		t.Errorf("expected type name fallback, got %q", res.Sources[1].Name)
 	}
 }
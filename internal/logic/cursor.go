@@ -0,0 +1,35 @@
+package logic
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned by Search when the After cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor returns an opaque cursor pointing just past p.
+func EncodeCursor(p Post) string {
+	raw := strconv.FormatInt(p.PublishedAt.UnixNano(), 10) + "|" + p.URL
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor turns a cursor back into the sort key of the post it points at.
+func DecodeCursor(cursor string) (Post, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Post{}, ErrInvalidCursor
+	}
+	ts, url, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Post{}, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return Post{}, ErrInvalidCursor
+	}
+	return Post{URL: url, PublishedAt: time.Unix(0, nanos)}, nil
+}
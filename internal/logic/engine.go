@@ -1,34 +1,127 @@
 // Package logic provides the core "brain" of the GRIP aggregator.
-// It implements a headless engine that uses a Fan-Out pattern to query multiple 
-// developer blog sources concurrently. Results are aggregated and sorted 
-// using a Min-Heap to ensure a constant memory footprint while returning 
-// only the 20 most recent posts.
+// It implements a headless engine that uses a Fan-Out pattern to query multiple
+// developer blog sources concurrently. Results are aggregated and sorted
+// using a Min-Heap to ensure a constant memory footprint while returning
+// only the most recent posts (20 by default).
 package logic
 
 import (
 	"container/heap"
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
+
+const (
+	// DefaultLimit is the page size used when a search does not ask for one.
+	DefaultLimit = 20
+	// MaxLimit caps the page size so the heap stays small.
+	MaxLimit = 100
+)
+
+// Per-source outcomes reported in SourceStatus.Status.
+const (
+	StatusOK      = "ok"
+	StatusError   = "error"
+	StatusTimeout = "timeout"
+)
+
 // Post represents a standardized blog post from any external source.
 type Post struct {
 	Title       string    `json:"title" example:"golang"`
 	URL         string    `json:"url" example:"https://dev.to/user/post"`
 	Source      string    `json:"source" example:"dev.to"`
 	PublishedAt time.Time `json:"published_at" example:"2026-01-21T10:00:00Z"`
+	// Author is the writer or submitter, when the source names one.
+	Author string `json:"author,omitempty" example:"gopher"`
+	// Score, Comments and DiscussionURL are only set by sources with community
+	// discussion, such as Reddit. Comments counts replies, which are answers
+	// on Q&A sites.
+	Score         int    `json:"score,omitempty" example:"42"`
+	Comments      int    `json:"comments,omitempty" example:"7"`
+	DiscussionURL string `json:"discussion_url,omitempty" example:"https://www.reddit.com/r/golang/comments/abc123/"`
 }
+
 // Source defines the contract for adding new source providers.
 type Source interface {
-	
 	Search(ctx context.Context, query string) ([]Post, error)
 }
 
-//resultsHeap implements heap.Interface to maintain a Top 20 list by date.
+// Named is implemented by sources that can report a display name for status reporting.
+type Named interface {
+	Name() string
+}
+
+// SourceName returns the display name of s, falling back to its Go type.
+func SourceName(s Source) string {
+	if n, ok := s.(Named); ok {
+		return n.Name()
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", s), "*")
+}
+
+// SearchOptions describes a single page of results.
+type SearchOptions struct {
+	Query string
+	// Limit is the page size; zero means DefaultLimit.
+	Limit int
+	// After is an opaque cursor taken from a previous SearchResult.NextCursor.
+	After string
+	// Sources restricts the search to the named sources; empty means all of them.
+	// Names are matched loosely, so "devto", "dev.to" and "Dev.to" are equivalent.
+	Sources []string
+	// Since drops posts published before it; the zero value keeps everything.
+	Since time.Time
+	// OnSource, when set, is called as soon as each source reports, with its
+	// status and up to Limit of its newest posts that pass After and Since.
+	// It runs on the goroutine that called Search, before the page is ranked.
+	OnSource func(status SourceStatus, posts []Post)
+}
+
+// UnknownSourceError is returned by Search when SearchOptions.Sources names a
+// source the engine does not have.
+type UnknownSourceError struct {
+	Name string
+}
+
+func (e *UnknownSourceError) Error() string {
+	return fmt.Sprintf("unknown source %q", e.Name)
+}
+
+// SourceStatus reports how a single source behaved during a search.
+type SourceStatus struct {
+	Name      string `json:"name" example:"Dev.to"`
+	Status    string `json:"status" example:"ok" enums:"ok,error,timeout"`
+	Count     int    `json:"count" example:"10"`
+	Error     string `json:"error,omitempty" example:"devto api error: status 503"`
+	LatencyMS int64  `json:"latency_ms" example:"312"`
+}
+
+// SearchResult is one page of posts together with per-source diagnostics.
+type SearchResult struct {
+	Posts      []Post
+	Sources    []SourceStatus
+	NextCursor string
+}
+
+// AllFailed reports whether at least one source was queried and none succeeded.
+func (r SearchResult) AllFailed() bool {
+	for _, s := range r.Sources {
+		if s.Status == StatusOK {
+			return false
+		}
+	}
+	return len(r.Sources) > 0
+}
+
+//resultsHeap implements heap.Interface to maintain a Top N list by date.
 type resultsHeap []Post
 
 func (h resultsHeap) Len() int           { return len(h) }
-func (h resultsHeap) Less(i, j int) bool { return h[i].PublishedAt.Before(h[j].PublishedAt) }
+func (h resultsHeap) Less(i, j int) bool { return newer(h[j], h[i]) }
 func (h resultsHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
 
 func (h *resultsHeap) Push(x interface{}) { *h = append(*h, x.(Post)) }
@@ -40,33 +133,127 @@ func (h *resultsHeap) Pop() interface{} {
 	return x
 }
 
+// newer orders posts newest first, breaking ties on URL so pages are stable.
+func newer(a, b Post) bool {
+	if !a.PublishedAt.Equal(b.PublishedAt) {
+		return a.PublishedAt.After(b.PublishedAt)
+	}
+	return a.URL < b.URL
+}
+
 type Engine struct {
 	Sources []Source
 }
+
+// SourceKey normalises a source name for loose matching, so "Dev.to" and
+// "devto" name the same source.
+func SourceKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(name))
+}
+
+// selectSources returns the engine sources named in names, or all of them when names is empty.
+func (e *Engine) selectSources(names []string) ([]Source, error) {
+	if len(names) == 0 {
+		return e.Sources, nil
+	}
+
+	byKey := make(map[string]Source, len(e.Sources))
+	for _, s := range e.Sources {
+		byKey[SourceKey(SourceName(s))] = s
+	}
+
+	selected := make([]Source, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := SourceKey(name)
+		s, ok := byKey[key]
+		if !ok {
+			return nil, &UnknownSourceError{Name: name}
+		}
+		if !seen[key] {
+			seen[key] = true
+			selected = append(selected, s)
+		}
+	}
+	return selected, nil
+}
+
+// CheckSources returns an UnknownSourceError if names includes a source the
+// engine does not have, so callers can validate a selection before storing it.
+func (e *Engine) CheckSources(names []string) error {
+	_, err := e.selectSources(names)
+	return err
+}
+
+// sourceResult is what each worker goroutine reports back to the collector.
+type sourceResult struct {
+	index   int
+	posts   []Post
+	err     error
+	latency time.Duration
+}
+
 // Collect triggers a concurrent fan-out at all sources and enforces a 2 second timeout rule and returns the 20 most recent posts.
 func (e *Engine) Collect(ctx context.Context, query string) []Post {
+	res, _ := e.Search(ctx, SearchOptions{Query: query})
+	return res.Posts
+}
+
+// Search fans out to every source like Collect, but returns one page of results
+// after opts.After along with the status of each source and the next cursor.
+func (e *Engine) Search(ctx context.Context, opts SearchOptions) (SearchResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var after *Post
+	if opts.After != "" {
+		p, err := DecodeCursor(opts.After)
+		if err != nil {
+			return SearchResult{}, err
+		}
+		after = &p
+	}
+
+	srcs, err := e.selectSources(opts.Sources)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
 	// Set a hard deadline for the entire collection process
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
 
 	h := &resultsHeap{}
 	heap.Init(h)
-	
+
+	statuses := make([]SourceStatus, len(srcs))
+	for i, s := range srcs {
+		statuses[i] = SourceStatus{Name: SourceName(s), Status: StatusTimeout}
+	}
+
 	// Buffered channel prevents worker goroutines from hanging if we exit early
-	resultsChan := make(chan []Post, len(e.Sources))
+	resultsChan := make(chan sourceResult, len(srcs))
 	var wg sync.WaitGroup
 
-	for _, s := range e.Sources {
+	start := time.Now()
+	for i, s := range srcs {
 		wg.Add(1)
-		go func(src Source) {
+		go func(i int, src Source) {
 			defer wg.Done()
 			// The context is passed to the search to cancel network calls if timeout hits
-			posts, err := src.Search(ctx, query)
-			if err != nil {
-				return
-			}
-			resultsChan <- posts
-		}(s)
+			posts, err := src.Search(ctx, opts.Query)
+			resultsChan <- sourceResult{index: i, posts: posts, err: err, latency: time.Since(start)}
+		}(i, s)
 	}
 
 	// This goroutine ensures the channel is closed so the loop can finish if all sources report in
@@ -75,24 +262,53 @@ func (e *Engine) Collect(ctx context.Context, query string) []Post {
 		close(resultsChan)
 	}()
 
+	truncated := false
 	finished := 0
 Loop:
-	for finished < len(e.Sources) {
+	for finished < len(srcs) {
 		select {
-		case posts, ok := <-resultsChan:
+		case res, ok := <-resultsChan:
 			if !ok {
 				break Loop
 			}
 			finished++
-			
-			for _, p := range posts {
-				if h.Len() < 20 {
+
+			status := &statuses[res.index]
+			status.LatencyMS = res.latency.Milliseconds()
+			if res.err != nil {
+				status.Status = StatusError
+				status.Error = res.err.Error()
+				if opts.OnSource != nil {
+					opts.OnSource(*status, nil)
+				}
+				continue
+			}
+			status.Status = StatusOK
+			status.Count = len(res.posts)
+
+			var kept []Post
+			for _, p := range res.posts {
+				if after != nil && !newer(*after, p) {
+					continue
+				}
+				if p.PublishedAt.Before(opts.Since) {
+					continue
+				}
+				kept = append(kept, p)
+				if h.Len() < limit {
 					heap.Push(h, p)
-				} else if p.PublishedAt.After((*h)[0].PublishedAt) {
+				} else if newer(p, (*h)[0]) {
 					heap.Pop(h)
 					heap.Push(h, p)
+					truncated = true
+				} else {
+					truncated = true
 				}
 			}
+			if opts.OnSource != nil {
+				sort.Slice(kept, func(i, j int) bool { return newer(kept[i], kept[j]) })
+				opts.OnSource(*status, kept[:min(len(kept), limit)])
+			}
 
 		case <-ctx.Done():
 			// 2-second timeout hit! Break and return what we have so far
@@ -100,16 +316,27 @@ Loop:
 		}
 	}
 
+	for i := range statuses {
+		if statuses[i].Status == StatusTimeout {
+			statuses[i].LatencyMS = time.Since(start).Milliseconds()
+		}
+	}
+
 	// Drain heap into a sorted "newest first" slice
 	final := make([]Post, h.Len())
 	for i := h.Len() - 1; i >= 0; i-- {
 		final[i] = heap.Pop(h).(Post)
 	}
-	return final
+
+	result := SearchResult{Posts: final, Sources: statuses}
+	if truncated && len(final) > 0 {
+		result.NextCursor = EncodeCursor(final[len(final)-1])
+	}
+	return result, nil
 }
 
 func NewEngine(source []Source) *Engine {
 	return &Engine{
 		Sources: source,
 	}
-}
\ No newline at end of file
+}
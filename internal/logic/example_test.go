@@ -10,5 +10,5 @@ func ExampleEngine_Collect() {
 	engine := logic.NewEngine([]logic.Source{ /* mock sources */ })
 	posts := engine.Collect(context.Background(), "golang")
 	fmt.Println(len(posts))
-	// Output: 20
+	// Output: 0
 }
\ No newline at end of file
@@ -0,0 +1,22 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaxQueryLength bounds a query so a single request cannot fan a huge string out to every source.
+const MaxQueryLength = 200
+
+// ValidateQuery reports whether q is safe to send to the sources.
+func ValidateQuery(q string) error {
+	if len(q) > MaxQueryLength {
+		return fmt.Errorf("q must be at most %d characters", MaxQueryLength)
+	}
+	if strings.IndexFunc(q, unicode.IsControl) >= 0 {
+		return errors.New("q must not contain control characters")
+	}
+	return nil
+}
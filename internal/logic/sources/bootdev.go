@@ -23,6 +23,8 @@ type bootRSS struct {
 	} `xml:"channel"`
 }
 
+func (b *BootDev) Name() string { return "Boot.dev" }
+
 func (b *BootDev) Search(ctx context.Context, query string) ([]logic.Post, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", "https://blog.boot.dev/index.xml", nil)
 	if err != nil {
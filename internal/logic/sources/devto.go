@@ -4,56 +4,88 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+
 	"github.com/Numpkens/grip/internal/logic"
-	"log"
 )
 
+// devtoTag matches queries that can be Dev.to tags, which are lowercase
+// letters and digits only. Anything else goes through the site search.
+var devtoTag = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// DevTo reads articles from Dev.to. A one-word query lists the articles of
+// that tag; longer queries use the site's full-text search.
 type DevTo struct {
-	Client  *http.Client
-	BaseURL string 
+	Client *http.Client
+	// BaseURL is the API root, https://dev.to/api when empty. Site search and
+	// the links of its results use the same scheme and host.
+	BaseURL string
+	// Top lists the most popular articles of the last Top days instead of
+	// the newest. It only applies to tag listings.
+	Top int
+	// State is "rising" or "fresh", and only applies to tag listings.
+	State string
+	// PerPage and Page select the page of results; zero uses Dev.to's defaults.
+	PerPage int
+	Page    int
+}
+
+func (d *DevTo) Name() string { return "Dev.to" }
+
+type devtoArticle struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	PublishedAt string `json:"published_at"`
+	Reactions   int    `json:"public_reactions_count"`
+	Comments    int    `json:"comments_count"`
+	User        struct {
+		Username string `json:"username"`
+	} `json:"user"`
 }
 
 func (d *DevTo) Search(ctx context.Context, query string) ([]logic.Post, error) {
-   
-	endpoint := d.BaseURL
-	if endpoint == "" {
-		endpoint = "https://dev.to/api"
+	base := d.BaseURL
+	if base == "" {
+		base = "https://dev.to/api"
 	}
-    
-	url := fmt.Sprintf("%s/articles?tag=%s", endpoint, query)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	endpoint, err := url.Parse(base)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("devto base URL: %w", err)
 	}
 
-	resp, err := d.Client.Do(req)
-	if err != nil {
-		return nil, err
+	tag := strings.ToLower(strings.TrimSpace(query))
+	if tag != "" && !devtoTag.MatchString(tag) {
+		return d.search(ctx, &url.URL{Scheme: endpoint.Scheme, Host: endpoint.Host}, query)
 	}
-	defer resp.Body.Close()
 
-   
-    if resp.StatusCode != http.StatusOK {
-        return nil, fmt.Errorf("devto api error: status %d", resp.StatusCode)
-    }
-
-	var payload []struct {
-		Title       string `json:"title"`
-		URL         string `json:"url"`
-		PublishedAt string `json:"published_at"`
+	params := d.pageParams()
+	if tag != "" {
+		params.Set("tag", tag)
+	}
+	if d.Top > 0 {
+		params.Set("top", strconv.Itoa(d.Top))
+	}
+	if d.State != "" {
+		params.Set("state", d.State)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, err 
+	var payload []devtoArticle
+	articles := endpoint.JoinPath("articles")
+	articles.RawQuery = params.Encode()
+	if err := d.get(ctx, articles.String(), &payload); err != nil {
+		return nil, err
 	}
 
 	var posts []logic.Post
 	for _, r := range payload {
 		parsedDate, err := time.Parse(time.RFC3339, r.PublishedAt)
-		if err != nil{
+		if err != nil {
 			log.Printf("Error return while parsing time stamp: %v", err)
 			continue
 		}
@@ -63,7 +95,87 @@ func (d *DevTo) Search(ctx context.Context, query string) ([]logic.Post, error)
 			URL:         r.URL,
 			Source:      "Dev.to",
 			PublishedAt: parsedDate,
+			Author:      r.User.Username,
+			Score:       r.Reactions,
+			Comments:    r.Comments,
+		})
+	}
+	return posts, nil
+}
+
+// search runs a full-text search of articles through the endpoint behind
+// the site's own search page, which lives outside /api.
+func (d *DevTo) search(ctx context.Context, site *url.URL, query string) ([]logic.Post, error) {
+	params := d.pageParams()
+	params.Set("search_fields", query)
+	params.Set("class_name", "Article")
+	params.Set("sort_by", "published_at")
+	params.Set("sort_direction", "desc")
+
+	var payload struct {
+		Result []struct {
+			Title              string `json:"title"`
+			Path               string `json:"path"`
+			PublishedTimestamp string `json:"published_timestamp"`
+			Reactions          int    `json:"public_reactions_count"`
+			Comments           int    `json:"comments_count"`
+			User               struct {
+				Username string `json:"username"`
+			} `json:"user"`
+		} `json:"result"`
+	}
+	search := site.JoinPath("search", "feed_content")
+	search.RawQuery = params.Encode()
+	if err := d.get(ctx, search.String(), &payload); err != nil {
+		return nil, err
+	}
+
+	var posts []logic.Post
+	for _, r := range payload.Result {
+		parsedDate, err := time.Parse(time.RFC3339, r.PublishedTimestamp)
+		if err != nil {
+			log.Printf("Error return while parsing time stamp: %v", err)
+			continue
+		}
+
+		posts = append(posts, logic.Post{
+			Title:       r.Title,
+			URL:         site.JoinPath(r.Path).String(),
+			Source:      "Dev.to",
+			PublishedAt: parsedDate,
+			Author:      r.User.Username,
+			Score:       r.Reactions,
+			Comments:    r.Comments,
 		})
 	}
 	return posts, nil
-}
\ No newline at end of file
+}
+
+func (d *DevTo) pageParams() url.Values {
+	params := url.Values{}
+	if d.PerPage > 0 {
+		params.Set("per_page", strconv.Itoa(d.PerPage))
+	}
+	if d.Page > 0 {
+		params.Set("page", strconv.Itoa(d.Page))
+	}
+	return params
+}
+
+func (d *DevTo) get(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("devto api error: status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
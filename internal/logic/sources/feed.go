@@ -0,0 +1,173 @@
+package sources
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// Feed searches the titles of an arbitrary RSS 2.0 or Atom feed.
+type Feed struct {
+	Client *http.Client
+	// Title is the display name reported in Post.Source.
+	Title string
+	URL   string
+}
+
+// rawFeed decodes both RSS (<rss><channel><item>) and Atom (<feed><entry>) documents.
+type rawFeed struct {
+	Channel struct {
+		Items []struct {
+			Title   string `xml:"title"`
+			Link    string `xml:"link"`
+			PubDate string `xml:"pubDate"`
+			Date    string `xml:"http://purl.org/dc/elements/1.1/ date"`
+		} `xml:"item"`
+	} `xml:"channel"`
+	Entries []struct {
+		Title string `xml:"title"`
+		Links []struct {
+			Rel  string `xml:"rel,attr"`
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+	} `xml:"entry"`
+}
+
+// feedItem is the common shape of an RSS item or Atom entry.
+type feedItem struct {
+	Title string
+	Link  string
+	Date  string
+}
+
+// feedDateLayouts covers the timestamp formats seen in the wild across RSS and Atom.
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+}
+
+func parseFeedDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (f *Feed) Name() string { return f.Title }
+
+func (f *Feed) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	items, err := fetchFeed(ctx, f.Client, f.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	q := strings.ToLower(query)
+	var posts []logic.Post
+	for _, item := range items {
+		if !strings.Contains(strings.ToLower(item.Title), q) {
+			continue
+		}
+		posts = append(posts, logic.Post{
+			Title:       item.Title,
+			URL:         item.Link,
+			Source:      f.Title,
+			PublishedAt: parseFeedDate(item.Date),
+		})
+	}
+	return posts, nil
+}
+
+// fetchFeed downloads and decodes an RSS or Atom document into feedItems.
+func fetchFeed(ctx context.Context, client *http.Client, url string) ([]feedItem, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "GripAggregator/1.0 (+https://github.com/Numpkens/grip)")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed error: status %d", resp.StatusCode)
+	}
+
+	var raw rawFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	var items []feedItem
+	for _, it := range raw.Channel.Items {
+		date := it.PubDate
+		if date == "" {
+			date = it.Date
+		}
+		items = append(items, feedItem{Title: strings.TrimSpace(it.Title), Link: strings.TrimSpace(it.Link), Date: date})
+	}
+	for _, e := range raw.Entries {
+		var link string
+		for _, l := range e.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		date := e.Published
+		if date == "" {
+			date = e.Updated
+		}
+		items = append(items, feedItem{Title: strings.TrimSpace(e.Title), Link: link, Date: date})
+	}
+	return items, nil
+}
+
+// fetchFeeds downloads urls concurrently, returning each feed's items at its
+// index. Failed feeds are logged and left nil; an error is only returned when
+// every feed failed.
+func fetchFeeds(ctx context.Context, client *http.Client, urls []string) ([][]feedItem, error) {
+	items := make([][]feedItem, len(urls))
+	errs := make([]error, len(urls))
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			items[i], errs[i] = fetchFeed(ctx, client, u)
+		}(i, u)
+	}
+	wg.Wait()
+
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			failed++
+			errs[i] = fmt.Errorf("%s: %w", urls[i], err)
+			log.Printf("Source error: %v", errs[i])
+		}
+	}
+	if failed > 0 && failed == len(urls) {
+		return nil, errors.Join(errs...)
+	}
+	return items, nil
+}
@@ -1,89 +1,143 @@
 package sources
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
+	"log"
 	"net/http"
-	"time"
+	"strings"
+	"sync"
+
 	"github.com/Numpkens/grip/internal/logic"
 )
 
+// freeCodeCampHost is the Hashnode host of the freeCodeCamp News publication.
+const freeCodeCampHost = "freecodecamp.org/news"
+
+const freeCodeCampIDQuery = `query($host: String!) {
+  publication(host: $host) { id }
+}`
+
+const freeCodeCampSearchQuery = `query($id: ObjectId!, $q: String!, $first: Int!, $after: String) {
+  searchPostsOfPublication(first: $first, after: $after, filter: { publicationId: $id, query: $q }) {
+    edges { node { ...PostFields } }
+    pageInfo { hasNextPage endCursor }
+  }
+}` + hashnodePostFields
+
+const freeCodeCampPostsQuery = `query($host: String!, $tags: [String!], $first: Int!, $after: String) {
+  publication(host: $host) {
+    posts(first: $first, after: $after, filter: { tagSlugs: $tags }) {
+      edges { node { ...PostFields } }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}` + hashnodePostFields
+
+// FreeCodeCamp searches freeCodeCamp News, which is published on Hashnode.
+// Queries run as a text search of the publication, keeping only posts that
+// mention every word, and fall back to the posts of the tag named by the query.
 type FreeCodeCamp struct {
-	Client  *http.Client
-	BaseURL string 
+	Client *http.Client
+	// BaseURL is the Hashnode GraphQL API; empty means gql.hashnode.com.
+	BaseURL string
+
+	// publicationID is looked up once; text search takes an ID, not a host.
+	mu            sync.Mutex
+	publicationID string
 }
 
+func (f *FreeCodeCamp) Name() string { return "FreeCodeCamp" }
+
+// Search runs the text search and the tag lookup at the same time, so the
+// fallback does not start on a context the search already used up. An error
+// is only returned when neither found anything, and it covers both.
 func (f *FreeCodeCamp) Search(ctx context.Context, query string) ([]logic.Post, error) {
-	
-	url := "https://gql.hashnode.com"
-
-	
-	jsonData := map[string]interface{}{
-		"query": `
-			query {
-				publication(host: "freecodecamp.org/news") {
-					posts(first: 10, filter: { tagSlugs: ["` + query + `"] }) {
-						edges {
-							node {
-								title
-								url
-								publishedAt
-							}
-						}
-					}
-				}
-			}`,
+	if strings.TrimSpace(query) == "" {
+		return f.posts(ctx, nil)
 	}
 
-	body, _ := json.Marshal(jsonData)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
+	var tagPosts []logic.Post
+	var tagErr error
+	var wg sync.WaitGroup
+	if slug := slugify(query); slug != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tagPosts, tagErr = f.posts(ctx, []string{slug})
+		}()
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := f.Client.Do(req)
+	posts, err := f.search(ctx, query)
+	wg.Wait()
+	if len(posts) > 0 {
+		return posts, nil
+	}
 	if err != nil {
-		return nil, err
+		log.Printf("FreeCodeCamp search: %v", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
+	if len(tagPosts) > 0 {
+		return tagPosts, nil
 	}
+	return nil, errors.Join(err, tagErr)
+}
 
+// search runs a text search of the publication, which also returns loose
+// matches, so only posts mentioning every word of query are kept.
+func (f *FreeCodeCamp) search(ctx context.Context, query string) ([]logic.Post, error) {
+	id, err := f.id(ctx)
+	if err != nil {
+		return nil, err
+	}
+	words := searchWords(query)
+	return hashnodePages("FreeCodeCamp", func(after string) (hashnodeConnection, error) {
+		var data struct {
+			SearchPostsOfPublication hashnodeConnection `json:"searchPostsOfPublication"`
+		}
+		vars := map[string]any{"id": id, "q": query, "first": hashnodePageSize, "after": hashnodeCursor(after)}
+		err := hashnodeQuery(ctx, f.Client, f.BaseURL, freeCodeCampSearchQuery, vars, &data)
+		return data.SearchPostsOfPublication, err
+	}, func(p hashnodePost) bool { return p.matches(words) })
+}
 
-	var response struct {
-		Data struct {
-			Publication struct {
-				Posts struct {
-					Edges []struct {
-						Node struct {
-							Title       string `json:"title"`
-							URL         string `json:"url"`
-							PublishedAt string `json:"publishedAt"`
-						} `json:"node"`
-					} `json:"edges"`
-				} `json:"posts"`
+// posts lists the publication's newest posts, limited to tags when given.
+func (f *FreeCodeCamp) posts(ctx context.Context, tags []string) ([]logic.Post, error) {
+	return hashnodePages("FreeCodeCamp", func(after string) (hashnodeConnection, error) {
+		var data struct {
+			Publication *struct {
+				Posts hashnodeConnection `json:"posts"`
 			} `json:"publication"`
-		} `json:"data"`
-	}
+		}
+		vars := map[string]any{"host": freeCodeCampHost, "tags": tags, "first": hashnodePageSize, "after": hashnodeCursor(after)}
+		if err := hashnodeQuery(ctx, f.Client, f.BaseURL, freeCodeCampPostsQuery, vars, &data); err != nil {
+			return hashnodeConnection{}, err
+		}
+		if data.Publication == nil {
+			return hashnodeConnection{}, errors.New("publication not found")
+		}
+		return data.Publication.Posts, nil
+	}, nil)
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, err
+// id returns the publication's ID, looking it up on first use.
+func (f *FreeCodeCamp) id(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.publicationID != "" {
+		return f.publicationID, nil
 	}
 
-	var posts []logic.Post
-	for _, edge := range response.Data.Publication.Posts.Edges {
-		parsedDate, _ := time.Parse(time.RFC3339, edge.Node.PublishedAt)
-		posts = append(posts, logic.Post{
-			Title:       edge.Node.Title,
-			URL:         edge.Node.URL,
-			Source:      "FreeCodeCamp",
-			PublishedAt: parsedDate,
-		})
+	var data struct {
+		Publication *struct {
+			ID string `json:"id"`
+		} `json:"publication"`
+	}
+	if err := hashnodeQuery(ctx, f.Client, f.BaseURL, freeCodeCampIDQuery, map[string]any{"host": freeCodeCampHost}, &data); err != nil {
+		return "", err
 	}
-	return posts, nil
-}
\ No newline at end of file
+	if data.Publication == nil || data.Publication.ID == "" {
+		return "", errors.New("publication not found")
+	}
+	f.publicationID = data.Publication.ID
+	return f.publicationID, nil
+}
@@ -0,0 +1,260 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// GitHub searches the releases of configured repositories, and optionally
+// their discussions, through the GitHub API.
+type GitHub struct {
+	Client *http.Client
+	// BaseURL is the REST API root; GraphQL is served from BaseURL + "/graphql".
+	BaseURL string
+	// Repos are "owner/name" pairs.
+	Repos []string
+	// Discussions also searches the repos' discussions. GitHub's GraphQL API
+	// requires authentication, so this is skipped without a Token.
+	Discussions bool
+	Token       string
+
+	// cache keeps each repo's releases with their ETag; 304 responses do not
+	// count against GitHub's rate limit, which is 60 an hour without a token.
+	mu    sync.Mutex
+	cache map[string]githubReleases
+}
+
+type githubReleases struct {
+	etag     string
+	releases []githubRelease
+}
+
+type githubRelease struct {
+	HTMLURL     string    `json:"html_url"`
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	Draft       bool      `json:"draft"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func (g *GitHub) Name() string { return "GitHub" }
+
+// Search returns the releases whose name, tag or notes mention query, plus
+// matching discussions when enabled. Failing repos are logged and skipped;
+// an error is only returned when nothing could be fetched.
+func (g *GitHub) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	var searches []func() ([]logic.Post, error)
+	for _, repo := range g.Repos {
+		searches = append(searches, func() ([]logic.Post, error) {
+			posts, err := g.searchReleases(ctx, repo, query)
+			if err != nil {
+				return nil, fmt.Errorf("github %s: %w", repo, err)
+			}
+			return posts, nil
+		})
+	}
+	if g.Discussions && g.Token != "" && len(g.Repos) > 0 {
+		searches = append(searches, func() ([]logic.Post, error) {
+			posts, err := g.searchDiscussions(ctx, query)
+			if err != nil {
+				return nil, fmt.Errorf("github discussions: %w", err)
+			}
+			return posts, nil
+		})
+	}
+	return searchAll(searches)
+}
+
+func (g *GitHub) endpoint() string {
+	if g.BaseURL != "" {
+		return strings.TrimSuffix(g.BaseURL, "/")
+	}
+	return "https://api.github.com"
+}
+
+func (g *GitHub) newRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "GripAggregator/1.0 (+https://github.com/Numpkens/grip)")
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if g.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.Token)
+	}
+	return req, nil
+}
+
+func (g *GitHub) searchReleases(ctx context.Context, repo, query string) ([]logic.Post, error) {
+	releases, err := g.releases(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	q := strings.ToLower(query)
+	var posts []logic.Post
+	for _, r := range releases {
+		if r.Draft || r.PublishedAt.IsZero() {
+			continue
+		}
+		name := r.Name
+		if name == "" {
+			name = r.TagName
+		}
+		if !strings.Contains(strings.ToLower(name+"\n"+r.TagName+"\n"+r.Body), q) {
+			continue
+		}
+		posts = append(posts, logic.Post{
+			Title:       repo + " " + name,
+			URL:         r.HTMLURL,
+			Source:      "GitHub",
+			PublishedAt: r.PublishedAt,
+		})
+	}
+	return posts, nil
+}
+
+// releases fetches the latest releases of repo, revalidating the cached copy.
+func (g *GitHub) releases(ctx context.Context, repo string) ([]githubRelease, error) {
+	req, err := g.newRequest(ctx, "GET", fmt.Sprintf("%s/repos/%s/releases?per_page=20", g.endpoint(), repo), nil)
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	cached, ok := g.cache[repo]
+	g.mu.Unlock()
+	if ok {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		return cached.releases, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("api error: status %d", resp.StatusCode)
+	}
+
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		g.mu.Lock()
+		if g.cache == nil {
+			g.cache = make(map[string]githubReleases)
+		}
+		g.cache[repo] = githubReleases{etag: etag, releases: releases}
+		g.mu.Unlock()
+	}
+	return releases, nil
+}
+
+const githubDiscussionsQuery = `query($q: String!) {
+  search(query: $q, type: DISCUSSION, first: 20) {
+    nodes {
+      ... on Discussion {
+        title
+        url
+        createdAt
+        upvoteCount
+        comments { totalCount }
+        repository { nameWithOwner }
+      }
+    }
+  }
+}`
+
+// searchDiscussions runs one GraphQL search across every configured repo.
+func (g *GitHub) searchDiscussions(ctx context.Context, query string) ([]logic.Post, error) {
+	terms := make([]string, 0, len(g.Repos)+1)
+	for _, repo := range g.Repos {
+		terms = append(terms, "repo:"+repo)
+	}
+	terms = append(terms, query)
+
+	body, err := json.Marshal(map[string]any{
+		"query":     githubDiscussionsQuery,
+		"variables": map[string]string{"q": strings.Join(terms, " ")},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := g.newRequest(ctx, "POST", g.endpoint()+"/graphql", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("api error: status %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Data struct {
+			Search struct {
+				Nodes []struct {
+					Title       string    `json:"title"`
+					URL         string    `json:"url"`
+					CreatedAt   time.Time `json:"createdAt"`
+					UpvoteCount int       `json:"upvoteCount"`
+					Comments    struct {
+						TotalCount int `json:"totalCount"`
+					} `json:"comments"`
+					Repository struct {
+						NameWithOwner string `json:"nameWithOwner"`
+					} `json:"repository"`
+				} `json:"nodes"`
+			} `json:"search"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+	if len(payload.Errors) > 0 {
+		return nil, errors.New(payload.Errors[0].Message)
+	}
+
+	var posts []logic.Post
+	for _, n := range payload.Data.Search.Nodes {
+		// Nodes that are not discussions decode as empty objects.
+		if n.URL == "" {
+			continue
+		}
+		posts = append(posts, logic.Post{
+			Title:         n.Repository.NameWithOwner + ": " + n.Title,
+			URL:           n.URL,
+			Source:        "GitHub",
+			PublishedAt:   n.CreatedAt,
+			Score:         n.UpvoteCount,
+			Comments:      n.Comments.TotalCount,
+			DiscussionURL: n.URL,
+		})
+	}
+	return posts, nil
+}
@@ -5,20 +5,65 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Numpkens/grip/internal/logic"
 )
 
+// hnPrefix matches a leading "Show HN" or "Ask HN" in a query, which limits
+// the search to those posts the way their titles are written.
+var hnPrefix = regexp.MustCompile(`(?i)^\s*(show|ask)\s+hn\b:?\s*`)
+
+// hnItem is where every Hacker News story is discussed.
+const hnItem = "https://news.ycombinator.com/item?id="
+
+// HackerNews searches Hacker News stories through the Algolia API.
 type HackerNews struct {
-	Client *http.Client
+	Client  *http.Client
+	BaseURL string
+	// ByDate sorts by submission time (search_by_date) rather than relevance.
+	ByDate bool
+	// MinPoints drops stories with fewer points.
+	MinPoints int
+	// Kind limits results to "show" (Show HN) or "ask" (Ask HN) posts. A
+	// query starting with "Show HN" or "Ask HN" overrides it.
+	Kind string
 }
 
+func (h *HackerNews) Name() string { return "Hacker News" }
+
 func (h *HackerNews) Search(ctx context.Context, query string) ([]logic.Post, error) {
-	
-	url := fmt.Sprintf("https://hn.algolia.com/api/v1/search?query=%s&tags=story", query)
+	endpoint := h.BaseURL
+	if endpoint == "" {
+		endpoint = "https://hn.algolia.com/api/v1"
+	}
+
+	kind := h.Kind
+	if m := hnPrefix.FindStringSubmatch(query); m != nil {
+		kind = strings.ToLower(m[1])
+		query = query[len(m[0]):]
+	}
+	tags := "story"
+	switch kind {
+	case "show":
+		tags = "show_hn"
+	case "ask":
+		tags = "ask_hn"
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	params := url.Values{"query": {query}, "tags": {tags}}
+	if h.MinPoints > 0 {
+		params.Set("numericFilters", fmt.Sprintf("points>=%d", h.MinPoints))
+	}
+	path := "/search"
+	if h.ByDate {
+		path = "/search_by_date"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+path+"?"+params.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -29,11 +74,19 @@ func (h *HackerNews) Search(ctx context.Context, query string) ([]logic.Post, er
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hn api error: status %d", resp.StatusCode)
+	}
+
 	var result struct {
 		Hits []struct {
-			Title     string    `json:"title"`
-			URL       string    `json:"url"`
-			CreatedAt time.Time `json:"created_at"`
+			ObjectID    string    `json:"objectID"`
+			Title       string    `json:"title"`
+			URL         string    `json:"url"`
+			Author      string    `json:"author"`
+			Points      int       `json:"points"`
+			NumComments int       `json:"num_comments"`
+			CreatedAt   time.Time `json:"created_at"`
 		} `json:"hits"`
 	}
 
@@ -43,12 +96,22 @@ func (h *HackerNews) Search(ctx context.Context, query string) ([]logic.Post, er
 
 	var posts []logic.Post
 	for _, hit := range result.Hits {
+		discussion := hnItem + hit.ObjectID
+		// Ask HN and other text posts have no link of their own.
+		link := hit.URL
+		if link == "" {
+			link = discussion
+		}
 		posts = append(posts, logic.Post{
-			Title:       hit.Title,
-			URL:         hit.URL,
-			Source:      "Hacker News",
-			PublishedAt: hit.CreatedAt,
+			Title:         hit.Title,
+			URL:           link,
+			Source:        "Hacker News",
+			PublishedAt:   hit.CreatedAt,
+			Author:        hit.Author,
+			Score:         hit.Points,
+			Comments:      hit.NumComments,
+			DiscussionURL: discussion,
 		})
 	}
 	return posts, nil
-}
\ No newline at end of file
+}
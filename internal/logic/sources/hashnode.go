@@ -4,90 +4,279 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+
 	"github.com/Numpkens/grip/internal/logic"
 )
 
+var (
+	// slugDrop matches the characters Hashnode leaves out of tag slugs.
+	slugDrop = regexp.MustCompile(`[^a-z0-9\s_-]+`)
+	// slugSep matches runs of word separators, which become one hyphen.
+	slugSep = regexp.MustCompile(`[\s_-]+`)
+)
+
+const (
+	// hashnodePageSize is both the page requested and the number of posts wanted.
+	hashnodePageSize = 20
+	// hashnodeMaxPages bounds how far back a free-text search pages through the feed.
+	hashnodeMaxPages = 3
+)
+
+// hashnodePostFields is the fragment every Hashnode query selects posts with.
+const hashnodePostFields = `
+fragment PostFields on Post {
+  title
+  brief
+  url
+  publishedAt
+  reactionCount
+  responseCount
+  author { username }
+  tags { slug name }
+}`
+
+const hashnodeFeedQuery = `query($first: Int!, $after: String) {
+  feed(first: $first, after: $after, filter: { type: RECENT }) {
+    edges { node { ...PostFields } }
+    pageInfo { hasNextPage endCursor }
+  }
+}` + hashnodePostFields
+
+const hashnodeTagQuery = `query($slug: String!, $first: Int!, $after: String) {
+  tag(slug: $slug) {
+    posts(first: $first, after: $after, filter: { sortBy: recent }) {
+      edges { node { ...PostFields } }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}` + hashnodePostFields
+
+// Hashnode searches the posts of the tag named by the query, falling back to
+// the recent Hashnode feed for posts mentioning every word of the query. The
+// API only offers text search within a single publication, so there is no
+// site-wide search to use instead.
 type Hashnode struct {
-	Client *http.Client
+	Client  *http.Client
+	BaseURL string
+}
+
+// hashnodeConnection is a page of posts in Hashnode's GraphQL API.
+type hashnodeConnection struct {
+	Edges []struct {
+		Node hashnodePost `json:"node"`
+	} `json:"edges"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+type hashnodePost struct {
+	Title         string    `json:"title"`
+	Brief         string    `json:"brief"`
+	URL           string    `json:"url"`
+	PublishedAt   time.Time `json:"publishedAt"`
+	ReactionCount int       `json:"reactionCount"`
+	ResponseCount int       `json:"responseCount"`
+	Author        struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Tags []struct {
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	} `json:"tags"`
 }
 
 func slugify(query string) string {
-	s := strings.ToLower(strings.TrimSpace(query))
-	s = strings.ReplaceAll(s, " ", "-")
-	s = strings.ReplaceAll(s, "_", "-")
-	reg := regexp.MustCompile(`[^a-z0-9-]+`)
-	s = reg.ReplaceAllString(s, "")
-	regMulti := regexp.MustCompile(`-+/`)
-	s = regMulti.ReplaceAllString(s, "-")
-	return strings.Trim(s, "-")
+	s := slugDrop.ReplaceAllString(strings.ToLower(query), "")
+	return strings.Trim(slugSep.ReplaceAllString(s, "-"), "-")
 }
 
+func (h *Hashnode) Name() string { return "Hashnode" }
+
+// Search prefers the tag, which reaches back past the newest few pages of the
+// feed. The feed is only scanned client-side, so it is the fallback, but both
+// are looked up at the same time so the fallback costs no extra time.
 func (h *Hashnode) Search(ctx context.Context, query string) ([]logic.Post, error) {
-	tagSlug := slugify(query)
-
-	queryStr := fmt.Sprintf(`{
-        tag(slug: "%s") {
-            posts(first: 10, filter: { sortBy: recent }) {
-                edges {
-                    node {
-                        title
-                        url
-                        publishedAt
-                    }
-                }
-            }
-        }
-    }`, tagSlug)
-
-	jsonData := map[string]string{"query": queryStr}
-	body, _ := json.Marshal(jsonData)
-
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://gql.hashnode.com", bytes.NewBuffer(body))
+	slug := slugify(query)
+	if slug == "" {
+		return h.feed(ctx, query)
+	}
+
+	var feedPosts []logic.Post
+	var feedErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		feedPosts, feedErr = h.feed(ctx, query)
+	}()
+
+	posts, err := h.tag(ctx, slug)
+	wg.Wait()
+	if len(posts) > 0 {
+		return posts, nil
+	}
 	if err != nil {
-		return nil, err
+		if feedErr != nil {
+			return nil, errors.Join(err, feedErr)
+		}
+		log.Printf("Hashnode tag %s: %v", slug, err)
+	}
+	return feedPosts, feedErr
+}
+
+// feed pages through the recent feed for posts mentioning every word of query.
+func (h *Hashnode) feed(ctx context.Context, query string) ([]logic.Post, error) {
+	words := searchWords(query)
+	return hashnodePages("Hashnode", func(after string) (hashnodeConnection, error) {
+		var data struct {
+			Feed hashnodeConnection `json:"feed"`
+		}
+		err := hashnodeQuery(ctx, h.Client, h.BaseURL, hashnodeFeedQuery, map[string]any{"first": hashnodePageSize, "after": hashnodeCursor(after)}, &data)
+		return data.Feed, err
+	}, func(p hashnodePost) bool { return p.matches(words) })
+}
+
+// tag lists the newest posts of the tag with the given slug.
+func (h *Hashnode) tag(ctx context.Context, slug string) ([]logic.Post, error) {
+	return hashnodePages("Hashnode", func(after string) (hashnodeConnection, error) {
+		var data struct {
+			Tag *struct {
+				Posts hashnodeConnection `json:"posts"`
+			} `json:"tag"`
+		}
+		if err := hashnodeQuery(ctx, h.Client, h.BaseURL, hashnodeTagQuery, map[string]any{"slug": slug, "first": hashnodePageSize, "after": hashnodeCursor(after)}, &data); err != nil {
+			return hashnodeConnection{}, err
+		}
+		// Unknown tags resolve to null rather than an error.
+		if data.Tag == nil {
+			return hashnodeConnection{}, nil
+		}
+		return data.Tag.Posts, nil
+	}, nil)
+}
+
+// matches reports whether every word appears as a whole word in the post's
+// title, brief or tags, so "go" does not match "google".
+func (p hashnodePost) matches(words []string) bool {
+	text := []string{p.Title, p.Brief}
+	for _, t := range p.Tags {
+		text = append(text, t.Slug, t.Name)
+	}
+	found := make(map[string]bool)
+	for _, w := range searchWords(strings.Join(text, " ")) {
+		found[w] = true
+	}
+	for _, w := range words {
+		if !found[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// searchWords splits text into lowercase words at anything that is not a
+// letter or digit.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// hashnodePages follows the cursors of fetch until it has a page worth of
+// posts that keep accepts, runs out of pages or reaches hashnodeMaxPages.
+// A nil keep accepts every post. Posts are reported as coming from source.
+// A failed page only fails the search when nothing was found before it.
+func hashnodePages(source string, fetch func(after string) (hashnodeConnection, error), keep func(hashnodePost) bool) ([]logic.Post, error) {
+	var posts []logic.Post
+	after := ""
+	for page := 0; page < hashnodeMaxPages && len(posts) < hashnodePageSize; page++ {
+		conn, err := fetch(after)
+		if err != nil {
+			if len(posts) > 0 {
+				log.Printf("%s page %d: %v", source, page+1, err)
+				return posts, nil
+			}
+			return nil, err
+		}
+		for _, e := range conn.Edges {
+			if keep != nil && !keep(e.Node) {
+				continue
+			}
+			posts = append(posts, logic.Post{
+				Title:       e.Node.Title,
+				URL:         e.Node.URL,
+				Source:      source,
+				PublishedAt: e.Node.PublishedAt,
+				Author:      e.Node.Author.Username,
+				Score:       e.Node.ReactionCount,
+				Comments:    e.Node.ResponseCount,
+			})
+		}
+		if !conn.PageInfo.HasNextPage || conn.PageInfo.EndCursor == "" {
+			break
+		}
+		after = conn.PageInfo.EndCursor
+	}
+	return posts, nil
+}
+
+// hashnodeCursor leaves the first page's cursor null, as the API expects.
+func hashnodeCursor(after string) any {
+	if after == "" {
+		return nil
+	}
+	return after
+}
+
+// hashnodeQuery runs a GraphQL query against Hashnode's API at endpoint,
+// gql.hashnode.com when empty, and decodes its data into out.
+func hashnodeQuery(ctx context.Context, client *http.Client, endpoint, query string, variables map[string]any, out any) error {
+	if endpoint == "" {
+		endpoint = "https://gql.hashnode.com"
+	}
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := h.Client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
-	var result struct {
-		Data struct {
-			Tag struct {
-				Posts struct {
-					Edges []struct {
-						Node struct {
-							Title       string `json:"title"`
-							URL         string `json:"url"`
-							PublishedAt string `json:"publishedAt"`
-						} `json:"node"`
-					} `json:"edges"`
-				} `json:"posts"`
-			} `json:"tag"`
-		} `json:"data"`
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: status %d", resp.StatusCode)
 	}
 
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+		return err
 	}
-
-	var posts []logic.Post
-	for _, edge := range result.Data.Tag.Posts.Edges {
-		parsedDate, _ := time.Parse(time.RFC3339, edge.Node.PublishedAt)
-		posts = append(posts, logic.Post{
-			Title:       edge.Node.Title,
-			URL:         edge.Node.URL,
-			Source:      "Hashnode",
-			PublishedAt: parsedDate,
-		})
+	if len(result.Errors) > 0 {
+		return errors.New(result.Errors[0].Message)
 	}
-	return posts, nil
-}
\ No newline at end of file
+	return json.Unmarshal(result.Data, out)
+}
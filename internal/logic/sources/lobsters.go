@@ -4,68 +4,170 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
+
 	"github.com/Numpkens/grip/internal/logic"
-	"log"
 )
 
+// lobstersTagsTTL is how long the tag list is trusted; tags change rarely.
+const lobstersTagsTTL = 24 * time.Hour
+
+// Lobsters reads stories from lobste.rs. A query made only of Lobsters tags
+// ("go", "go rust") reads those tags' listing; anything else goes through the
+// site's search.
 type Lobsters struct {
 	Client  *http.Client
-	BaseURL string 
+	BaseURL string
+
+	mu        sync.Mutex
+	tags      map[string]bool
+	tagsFetch time.Time
+}
+
+func (l *Lobsters) Name() string { return "Lobsters" }
+
+type lobstersStory struct {
+	Title        string       `json:"title"`
+	URL          string       `json:"url"`
+	PublishedAt  string       `json:"created_at"`
+	Score        int          `json:"score"`
+	CommentCount int          `json:"comment_count"`
+	CommentsURL  string       `json:"comments_url"`
+	Submitter    lobstersUser `json:"submitter_user"`
+}
+
+// lobstersUser is the submitter's name. Older versions of the site sent a
+// user object rather than the bare name.
+type lobstersUser string
+
+func (u *lobstersUser) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*u = lobstersUser(name)
+		return nil
+	}
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := json.Unmarshal(data, &user); err != nil {
+		return err
+	}
+	*u = lobstersUser(user.Username)
+	return nil
 }
 
 func (l *Lobsters) Search(ctx context.Context, query string) ([]logic.Post, error) {
-   
 	endpoint := l.BaseURL
 	if endpoint == "" {
 		endpoint = "https://lobste.rs"
 	}
-    
-	url := fmt.Sprintf("%s/t/%s.json", endpoint, query)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
+	var u string
+	if strings.TrimSpace(query) == "" {
+		u = endpoint + "/newest.json"
+	} else if tags := l.matchTags(ctx, endpoint, query); tags != nil {
+		u = fmt.Sprintf("%s/t/%s.json", endpoint, strings.Join(tags, ","))
+	} else {
+		params := url.Values{"q": {query}, "what": {"stories"}, "order": {"newest"}}
+		u = endpoint + "/search.json?" + params.Encode()
 	}
 
-	req.Header.Set("User-Agent", "GripAggregator/1.0 (+https://github.com/Numpkens/grip; numpkins1222@gmail.com)")
-
-	resp, err := l.Client.Do(req)
-	if err != nil {
+	var stories []lobstersStory
+	if err := l.get(ctx, u, &stories); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-   
-    if resp.StatusCode != http.StatusOK {
-        return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
-    }
-
-	var payload []struct {
-		Title       string `json:"title"`
-		URL         string `json:"url"`
-		PublishedAt string `json:"created_at"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, err 
-	}
 
 	var posts []logic.Post
-	for _, r := range payload {
+	for _, r := range stories {
 		parsedDate, err := time.Parse(time.RFC3339, r.PublishedAt)
-		if err != nil{
+		if err != nil {
 			log.Printf("Error return while parsing time stamp: %v", err)
 			continue
 		}
+		// Text posts have no link of their own.
+		link := r.URL
+		if link == "" {
+			link = r.CommentsURL
+		}
 
 		posts = append(posts, logic.Post{
-			Title:       r.Title,
-			URL:         r.URL,
-			Source:      "Lobsters",
-			PublishedAt: parsedDate,
+			Title:         r.Title,
+			URL:           link,
+			Source:        "Lobsters",
+			PublishedAt:   parsedDate,
+			Author:        string(r.Submitter),
+			Score:         r.Score,
+			Comments:      r.CommentCount,
+			DiscussionURL: r.CommentsURL,
 		})
 	}
 	return posts, nil
-}
\ No newline at end of file
+}
+
+// matchTags returns the tags named by query when every word of it is a
+// Lobsters tag, and nil otherwise.
+func (l *Lobsters) matchTags(ctx context.Context, endpoint, query string) []string {
+	known, err := l.tagList(ctx, endpoint)
+	if err != nil {
+		log.Printf("Lobsters tags: %v", err)
+		return nil
+	}
+	var tags []string
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !known[word] {
+			return nil
+		}
+		tags = append(tags, word)
+	}
+	return tags
+}
+
+// tagList returns the site's active tags, fetching them at most once a day.
+func (l *Lobsters) tagList(ctx context.Context, endpoint string) (map[string]bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tags != nil && time.Since(l.tagsFetch) < lobstersTagsTTL {
+		return l.tags, nil
+	}
+
+	var payload []struct {
+		Tag    string `json:"tag"`
+		Active bool   `json:"active"`
+	}
+	if err := l.get(ctx, endpoint+"/tags.json", &payload); err != nil {
+		return nil, err
+	}
+	tags := make(map[string]bool, len(payload))
+	for _, t := range payload {
+		if t.Active {
+			tags[t.Tag] = true
+		}
+	}
+	l.tags, l.tagsFetch = tags, time.Now()
+	return tags, nil
+}
+
+func (l *Lobsters) get(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("User-Agent", "GripAggregator/1.0 (+https://github.com/Numpkens/grip; numpkins1222@gmail.com)")
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
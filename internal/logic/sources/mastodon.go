@@ -0,0 +1,156 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+var (
+	// nonHashtag matches what Mastodon hashtags cannot contain.
+	nonHashtag = regexp.MustCompile(`[^\pL\pN_]+`)
+	// htmlTag matches the markup in status content.
+	htmlTag = regexp.MustCompile(`<[^>]*>`)
+)
+
+// mastodonTitleLen caps titles taken from status text when a status has no link card.
+const mastodonTitleLen = 100
+
+// Mastodon reads the public hashtag timeline named by the query on each
+// configured instance. Link cards become the post URL, and boosts and
+// statuses federated to several instances are reported once.
+type Mastodon struct {
+	Client *http.Client
+	// Instances are host names ("hachyderm.io") or base URLs.
+	Instances []string
+}
+
+func (m *Mastodon) Name() string { return "Mastodon" }
+
+type mastodonStatus struct {
+	URL             string          `json:"url"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Content         string          `json:"content"`
+	FavouritesCount int             `json:"favourites_count"`
+	RepliesCount    int             `json:"replies_count"`
+	Reblog          *mastodonStatus `json:"reblog"`
+	Card            *struct {
+		URL   string `json:"url"`
+		Title string `json:"title"`
+	} `json:"card"`
+}
+
+// mastodonHashtag turns a query into a hashtag: "Go Lang" becomes "golang".
+func mastodonHashtag(query string) string {
+	return strings.ToLower(nonHashtag.ReplaceAllString(strings.TrimPrefix(query, "#"), ""))
+}
+
+func (m *Mastodon) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	tag := mastodonHashtag(query)
+	if tag == "" || len(m.Instances) == 0 {
+		return nil, nil
+	}
+
+	var searches []func() ([]logic.Post, error)
+	for _, instance := range m.Instances {
+		searches = append(searches, func() ([]logic.Post, error) {
+			statuses, err := m.timeline(ctx, instance, tag)
+			if err != nil {
+				return nil, fmt.Errorf("mastodon %s: %w", instance, err)
+			}
+			posts := make([]logic.Post, 0, len(statuses))
+			for _, s := range statuses {
+				posts = append(posts, statusPost(s))
+			}
+			return posts, nil
+		})
+	}
+	all, err := searchAll(searches)
+	if err != nil {
+		return nil, err
+	}
+
+	// A boost or a status federated to several instances keeps the original's
+	// URL, and several people sharing one article still make one post.
+	seen := make(map[string]bool)
+	var posts []logic.Post
+	for _, p := range all {
+		if p.URL == "" || seen[p.DiscussionURL] || seen[p.URL] {
+			continue
+		}
+		seen[p.DiscussionURL], seen[p.URL] = true, true
+		posts = append(posts, p)
+	}
+	return posts, nil
+}
+
+// statusPost maps a status, or the status it boosts, to a post.
+func statusPost(s mastodonStatus) logic.Post {
+	if s.Reblog != nil {
+		s = *s.Reblog
+	}
+	p := logic.Post{
+		URL:           s.URL,
+		Source:        "Mastodon",
+		PublishedAt:   s.CreatedAt,
+		Score:         s.FavouritesCount,
+		Comments:      s.RepliesCount,
+		DiscussionURL: s.URL,
+	}
+	if s.Card != nil && s.Card.URL != "" {
+		p.URL, p.Title = s.Card.URL, s.Card.Title
+	}
+	if p.Title == "" {
+		p.Title = statusText(s.Content, mastodonTitleLen)
+	}
+	return p
+}
+
+func (m *Mastodon) timeline(ctx context.Context, instance, tag string) ([]mastodonStatus, error) {
+	base := strings.TrimSuffix(instance, "/")
+	if !strings.Contains(base, "://") {
+		base = "https://" + base
+	}
+	u := fmt.Sprintf("%s/api/v1/timelines/tag/%s?limit=40", base, url.PathEscape(tag))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "GripAggregator/1.0 (+https://github.com/Numpkens/grip)")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("api error: status %d", resp.StatusCode)
+	}
+
+	var statuses []mastodonStatus
+	if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// statusText flattens status HTML to plain text of at most n runes.
+func statusText(content string, n int) string {
+	content = strings.NewReplacer("</p>", " ", "<br>", " ", "<br/>", " ", "<br />", " ").Replace(content)
+	text := strings.Join(strings.Fields(html.UnescapeString(htmlTag.ReplaceAllString(content, ""))), " ")
+	if r := []rune(text); len(r) > n {
+		return strings.TrimSpace(string(r[:n-1])) + "…"
+	}
+	return text
+}
@@ -0,0 +1,89 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// nonSlug matches the characters Medium drops or hyphenates in tag slugs.
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Medium reads the RSS feed of the tag named by the query, plus the feeds of
+// configured publications, whose posts are matched on title.
+type Medium struct {
+	Client  *http.Client
+	BaseURL string
+	// Publications are publication slugs ("netflix-techblog") or "@user" names.
+	Publications []string
+}
+
+func (m *Medium) Name() string { return "Medium" }
+
+// mediumTag turns a query into a tag slug: "Machine Learning" becomes "machine-learning".
+func mediumTag(query string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(query), "-"), "-")
+}
+
+func (m *Medium) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	endpoint := m.BaseURL
+	if endpoint == "" {
+		endpoint = "https://medium.com"
+	}
+
+	var urls []string
+	tag := mediumTag(query)
+	if tag != "" {
+		urls = append(urls, endpoint+"/feed/tag/"+tag)
+	}
+	for _, p := range m.Publications {
+		urls = append(urls, endpoint+"/feed/"+url.PathEscape(p))
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	feeds, err := fetchFeeds(ctx, m.Client, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	q := strings.ToLower(query)
+	seen := make(map[string]bool)
+	var posts []logic.Post
+	for i, items := range feeds {
+		// Everything in the tag feed is on topic; publications cover anything.
+		fromTag := tag != "" && i == 0
+		for _, item := range items {
+			if !fromTag && !strings.Contains(strings.ToLower(item.Title), q) {
+				continue
+			}
+			link := stripQuery(item.Link)
+			if seen[link] {
+				continue
+			}
+			seen[link] = true
+			posts = append(posts, logic.Post{
+				Title:       item.Title,
+				URL:         link,
+				Source:      "Medium",
+				PublishedAt: parseFeedDate(item.Date),
+			})
+		}
+	}
+	return posts, nil
+}
+
+// stripQuery drops the "?source=rss-..." tracking parameters Medium adds to feed links.
+func stripQuery(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	u.RawQuery = ""
+	return u.String()
+}
@@ -0,0 +1,127 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// redditWeb is where permalinks point, whatever BaseURL the API is read from.
+const redditWeb = "https://www.reddit.com"
+
+// defaultRedditUserAgent follows Reddit's "<platform>:<app id>:<version>" rule;
+// generic agents such as Go's default are heavily throttled.
+const defaultRedditUserAgent = "server:github.com/Numpkens/grip:v1.0 (+https://github.com/Numpkens/grip)"
+
+// Reddit searches a set of subreddits through Reddit's public JSON endpoints,
+// combining them into one multireddit request.
+type Reddit struct {
+	Client     *http.Client
+	BaseURL    string
+	Subreddits []string
+	UserAgent  string
+}
+
+func (r *Reddit) Name() string { return "Reddit" }
+
+// redditListing is the envelope of both the search and the listing endpoints.
+type redditListing struct {
+	Data struct {
+		Children []struct {
+			Data struct {
+				Title       string  `json:"title"`
+				URL         string  `json:"url"`
+				Permalink   string  `json:"permalink"`
+				CreatedUTC  float64 `json:"created_utc"`
+				Score       int     `json:"score"`
+				NumComments int     `json:"num_comments"`
+				Stickied    bool    `json:"stickied"`
+				Over18      bool    `json:"over_18"`
+			} `json:"data"`
+		} `json:"children"`
+	} `json:"data"`
+}
+
+// Search returns the newest posts matching query in the configured
+// subreddits. An empty query reads the subreddits' "new" listing instead.
+func (r *Reddit) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	if len(r.Subreddits) == 0 {
+		return nil, nil
+	}
+
+	endpoint := r.BaseURL
+	if endpoint == "" {
+		endpoint = redditWeb
+	}
+	multi := url.PathEscape(strings.Join(r.Subreddits, "+"))
+
+	params := url.Values{"limit": {"25"}, "raw_json": {"1"}}
+	var u string
+	if query == "" {
+		u = fmt.Sprintf("%s/r/%s/new.json?%s", endpoint, multi, params.Encode())
+	} else {
+		params.Set("q", query)
+		params.Set("restrict_sr", "on")
+		params.Set("sort", "new")
+		u = fmt.Sprintf("%s/r/%s/search.json?%s", endpoint, multi, params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	ua := r.UserAgent
+	if ua == "" {
+		ua = defaultRedditUserAgent
+	}
+	req.Header.Set("User-Agent", ua)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reddit api error: status %d", resp.StatusCode)
+	}
+
+	var listing redditListing
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return nil, err
+	}
+
+	var posts []logic.Post
+	for _, c := range listing.Data.Children {
+		d := c.Data
+		// Pinned moderator posts and NSFW links are never what a search is after.
+		if d.Stickied || d.Over18 || d.Permalink == "" {
+			continue
+		}
+		discussion := redditWeb + d.Permalink
+		link := d.URL
+		if link == "" {
+			link = discussion
+		}
+		sec, frac := math.Modf(d.CreatedUTC)
+
+		posts = append(posts, logic.Post{
+			Title:         d.Title,
+			URL:           link,
+			Source:        "Reddit",
+			PublishedAt:   time.Unix(int64(sec), int64(frac*1e9)).UTC(),
+			Score:         d.Score,
+			Comments:      d.NumComments,
+			DiscussionURL: discussion,
+		})
+	}
+	return posts, nil
+}
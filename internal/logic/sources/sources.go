@@ -0,0 +1,96 @@
+// Package sources contains the provider adapters that satisfy logic.Source.
+package sources
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// builtins returns the providers that are always on, tuned by cfg.
+func builtins(cfg config.Sources, client *http.Client) []logic.Source {
+	return []logic.Source{
+		&DevTo{Client: client, Top: cfg.DevTo.Top, State: cfg.DevTo.State, PerPage: cfg.DevTo.PerPage, Page: cfg.DevTo.Page},
+		&HackerNews{Client: client, ByDate: cfg.HackerNews.Sort == "date", MinPoints: cfg.HackerNews.MinPoints, Kind: cfg.HackerNews.Kind},
+		&Hashnode{Client: client},
+		&BootDev{Client: client},
+		&Lobsters{Client: client, BaseURL: "https://lobste.rs"},
+		&FreeCodeCamp{Client: client},
+	}
+}
+
+// FromConfig returns the built-in providers plus every source configured in cfg.
+func FromConfig(cfg config.Sources, client *http.Client) []logic.Source {
+	srcs := builtins(cfg, client)
+	if len(cfg.Reddit.Subreddits) > 0 {
+		srcs = append(srcs, &Reddit{Client: client, Subreddits: cfg.Reddit.Subreddits, UserAgent: cfg.Reddit.UserAgent})
+	}
+	if len(cfg.GitHub.Repos) > 0 {
+		srcs = append(srcs, &GitHub{Client: client, Repos: cfg.GitHub.Repos, Discussions: cfg.GitHub.Discussions, Token: cfg.GitHub.Token})
+	}
+	if cfg.Medium.Enabled {
+		srcs = append(srcs, &Medium{Client: client, Publications: cfg.Medium.Publications})
+	}
+	if len(cfg.Substack.Publications) > 0 {
+		srcs = append(srcs, &Substack{Client: client, Publications: cfg.Substack.Publications})
+	}
+	if len(cfg.StackExchange.Sites) > 0 {
+		srcs = append(srcs, &StackExchange{Client: client, Sites: cfg.StackExchange.Sites, MinScore: cfg.StackExchange.MinScore, Key: cfg.StackExchange.Key})
+	}
+	if len(cfg.Mastodon.Instances) > 0 {
+		srcs = append(srcs, &Mastodon{Client: client, Instances: cfg.Mastodon.Instances})
+	}
+	for _, f := range cfg.Feeds {
+		srcs = append(srcs, &Feed{Client: client, Title: f.Name, URL: f.URL})
+	}
+	return srcs
+}
+
+// NewHTTPClient returns the pooled client the long-running servers share across adapters.
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 2 * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 20,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// searchAll runs searches concurrently and merges their posts. Failures are
+// logged and skipped, and an error is only returned when every search failed,
+// because the engine discards all of a source's posts when it reports one.
+func searchAll(searches []func() ([]logic.Post, error)) ([]logic.Post, error) {
+	results := make([][]logic.Post, len(searches))
+	errs := make([]error, len(searches))
+	var wg sync.WaitGroup
+	for i, search := range searches {
+		wg.Add(1)
+		go func(i int, search func() ([]logic.Post, error)) {
+			defer wg.Done()
+			results[i], errs[i] = search()
+		}(i, search)
+	}
+	wg.Wait()
+
+	var posts []logic.Post
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			failed++
+			log.Printf("Source error: %v", err)
+			continue
+		}
+		posts = append(posts, results[i]...)
+	}
+	if failed > 0 && failed == len(searches) {
+		return nil, errors.Join(errs...)
+	}
+	return posts, nil
+}
@@ -1,13 +1,102 @@
 package sources
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"github.com/Numpkens/grip/internal/logic"
 	"github.com/stretchr/testify/assert"
 )
 
+// fixture returns the contents of testdata/name.
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// fakeAPI is a test server standing in for a source's API. It records a line
+// for every request so tests can check what a search asked for.
+type fakeAPI struct {
+	*httptest.Server
+	mu    sync.Mutex
+	calls []string
+}
+
+// newFakeAPI serves requests with handle, recording each request URI.
+func newFakeAPI(t *testing.T, handle http.HandlerFunc) *fakeAPI {
+	api := &fakeAPI{}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.record(r.URL.RequestURI())
+		handle(w, r)
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// newGraphQLAPI serves GraphQL requests with handle, recording the
+// description it returns for each.
+func newGraphQLAPI(t *testing.T, handle func(w http.ResponseWriter, query string, vars map[string]any) string) *fakeAPI {
+	api := &fakeAPI{}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		api.record(handle(w, req.Query, req.Variables))
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func (a *fakeAPI) record(call string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, call)
+}
+
+// Calls returns the requests recorded since the last Reset.
+func (a *fakeAPI) Calls() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.calls...)
+}
+
+func (a *fakeAPI) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = nil
+}
+
+// files answers each request with the body listed for its path, or a 404.
+func files(bodies map[string][]byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	}
+}
+
 func TestDevTo_Search_Robustness(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -25,4 +114,501 @@ func TestDevTo_Search_Robustness(t *testing.T) {
 
 	assert.Error(t, err, "Should return an error for malformed JSON")
 	assert.Nil(t, posts)
-}
\ No newline at end of file
+}
+func TestFeed_Search_RSSAndAtom(t *testing.T) {
+	docs := map[string]string{
+		"/rss": `<?xml version="1.0"?><rss version="2.0"><channel>
+			<item><title>Go 1.26 is released</title><link>https://example.com/go126</link><pubDate>Tue, 10 Feb 2026 16:00:00 +0000</pubDate></item>
+			<item><title>Rust news</title><link>https://example.com/rust</link><pubDate>Tue, 10 Feb 2026 16:00:00 +0000</pubDate></item>
+		</channel></rss>`,
+		"/atom": `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom">
+			<entry><title>Profiling Go</title><link rel="alternate" href="https://example.com/pgo"/><published>2026-02-11T09:00:00Z</published></entry>
+		</feed>`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(docs[r.URL.Path]))
+	}))
+	defer ts.Close()
+
+	rss := &Feed{Client: ts.Client(), Title: "RSS Blog", URL: ts.URL + "/rss"}
+	posts, err := rss.Search(context.Background(), "go")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1) {
+		assert.Equal(t, "https://example.com/go126", posts[0].URL)
+		assert.Equal(t, "RSS Blog", posts[0].Source)
+		assert.Equal(t, 2026, posts[0].PublishedAt.Year())
+	}
+
+	atom := &Feed{Client: ts.Client(), Title: "Atom Blog", URL: ts.URL + "/atom"}
+	posts, err = atom.Search(context.Background(), "GO")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1) {
+		assert.Equal(t, "https://example.com/pgo", posts[0].URL)
+		assert.False(t, posts[0].PublishedAt.IsZero())
+	}
+}
+
+func TestReddit_Search_Fixture(t *testing.T) {
+	data := fixture(t, "reddit_search.json")
+	var gotUA string
+	api := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+
+	r := &Reddit{Client: api.Client(), BaseURL: api.URL, Subreddits: []string{"golang", "programming"}}
+	posts, err := r.Search(context.Background(), "go 1.26")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(gotUA, "server:github.com/Numpkens/grip:"), gotUA)
+
+	// The stickied and NSFW posts are dropped.
+	if assert.Len(t, posts, 2) {
+		assert.Equal(t, "https://go.dev/blog/go1.26", posts[0].URL)
+		assert.Equal(t, "https://www.reddit.com/r/golang/comments/1ia0b22/go_126_is_released/", posts[0].DiscussionURL)
+		assert.Equal(t, 412, posts[0].Score)
+		assert.Equal(t, 97, posts[0].Comments)
+		assert.Equal(t, time.Date(2026, 2, 10, 16, 0, 0, 0, time.UTC), posts[0].PublishedAt)
+		// Self posts link to their own thread.
+		assert.Equal(t, posts[1].DiscussionURL, posts[1].URL)
+	}
+
+	r.UserAgent = "test:grip:v0 (by /u/someone)"
+	_, err = r.Search(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "test:grip:v0 (by /u/someone)", gotUA)
+
+	calls := api.Calls()
+	if assert.Len(t, calls, 2) {
+		assert.True(t, strings.HasPrefix(calls[0], "/r/golang+programming/search.json?"), calls[0])
+		assert.Contains(t, calls[0], "q=go+1.26")
+		assert.True(t, strings.HasPrefix(calls[1], "/r/golang+programming/new.json"), calls[1])
+	}
+}
+
+func TestGitHub_Search_Fixtures(t *testing.T) {
+	releases := fixture(t, "github_releases.json")
+	discussions := fixture(t, "github_discussions.json")
+
+	var notModified atomic.Int32
+	var graphQLQuery string
+	api := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/golang/go/releases":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			w.Write(releases)
+		case "/graphql":
+			var body struct {
+				Variables struct {
+					Q string `json:"q"`
+				} `json:"variables"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			graphQLQuery = body.Variables.Q
+			w.Write(discussions)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	g := &GitHub{Client: api.Client(), BaseURL: api.URL, Repos: []string{"golang/go", "missing/repo"}, Discussions: true, Token: "secret"}
+	posts, err := g.Search(context.Background(), "garbage collector")
+	assert.NoError(t, err, "one failing repo should not fail the source")
+	assert.Equal(t, "repo:golang/go repo:missing/repo garbage collector", graphQLQuery)
+
+	urls := map[string]logic.Post{}
+	for _, p := range posts {
+		urls[p.URL] = p
+	}
+	assert.Len(t, posts, 2)
+	release := urls["https://github.com/golang/go/releases/tag/go1.26.0"]
+	assert.Equal(t, "golang/go Go 1.26", release.Title)
+	assert.Equal(t, time.Date(2026, 2, 10, 16, 0, 0, 0, time.UTC), release.PublishedAt)
+	discussion := urls["https://github.com/golang/go/discussions/70001"]
+	assert.Equal(t, 321, discussion.Score)
+	assert.Equal(t, 58, discussion.Comments)
+
+	// The second search revalidates with the ETag and reuses the cached releases.
+	g.Discussions = false
+	posts, err = g.Search(context.Background(), "crypto/tls")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1) {
+		assert.Equal(t, "golang/go go1.25.7", posts[0].Title)
+	}
+	var releaseHits int
+	for _, c := range api.Calls() {
+		if strings.HasPrefix(c, "/repos/golang/go/releases") {
+			releaseHits++
+		}
+	}
+	assert.Equal(t, 2, releaseHits)
+	assert.Equal(t, int32(1), notModified.Load())
+
+	g.Repos = []string{"missing/repo"}
+	_, err = g.Search(context.Background(), "go")
+	assert.Error(t, err)
+}
+
+func TestMedium_Search_TagAndPublications(t *testing.T) {
+	api := newFakeAPI(t, files(map[string][]byte{
+		"/feed/tag/golang":       fixture(t, "medium_tag_golang.xml"),
+		"/feed/netflix-techblog": fixture(t, "medium_publication.xml"),
+	}))
+
+	assert.Equal(t, "machine-learning", mediumTag(" Machine  Learning!"))
+
+	m := &Medium{Client: api.Client(), BaseURL: api.URL, Publications: []string{"netflix-techblog", "gone"}}
+	posts, err := m.Search(context.Background(), "Golang")
+	assert.NoError(t, err)
+
+	// Both tag posts, plus the publication post matching on title; the post
+	// in both feeds appears once, without Medium's tracking parameters.
+	var urls []string
+	for _, p := range posts {
+		urls = append(urls, p.URL)
+	}
+	assert.ElementsMatch(t, []string{
+		"https://medium.com/@gopher/structured-concurrency-in-go-1a2b3c4d5e6f",
+		"https://medium.com/netflix-techblog/why-we-moved-0f9e8d7c6b5a",
+		"https://medium.com/netflix-techblog/scaling-golang-9a8b7c6d5e4f",
+	}, urls)
+	assert.Equal(t, 2026, posts[0].PublishedAt.Year())
+}
+
+func TestSubstack_Search_Publications(t *testing.T) {
+	assert.Equal(t, "https://pragmaticengineer.substack.com/feed", substackFeed("pragmaticengineer"))
+	assert.Equal(t, "https://newsletter.pragmaticengineer.com/feed", substackFeed("newsletter.pragmaticengineer.com/"))
+
+	api := newFakeAPI(t, files(map[string][]byte{"/feed": fixture(t, "substack_feed.xml")}))
+
+	s := &Substack{Client: api.Client(), Publications: []string{api.URL}}
+	posts, err := s.Search(context.Background(), "go")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1) {
+		assert.Equal(t, "https://newsletter.pragmaticengineer.com/p/go-at-big-tech", posts[0].URL)
+		assert.Equal(t, "Substack", posts[0].Source)
+	}
+}
+
+func TestStackExchange_Search_GzipAndBackoff(t *testing.T) {
+	data := fixture(t, "stackexchange_search.json")
+	api := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("site") == "serverfault" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error_id": 502, "error_name": "throttle_violation", "error_message": "too many requests from this IP"}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write(data)
+		gz.Close()
+	})
+
+	s := &StackExchange{Client: api.Client(), BaseURL: api.URL, Sites: []string{"stackoverflow", "serverfault"}, MinScore: 1}
+	posts, err := s.Search(context.Background(), "type parameter")
+	assert.NoError(t, err, "one failing site should not fail the source")
+	if assert.Len(t, posts, 1, "questions below the minimum score are dropped") {
+		assert.Equal(t, "Why can't Go infer this type parameter?", posts[0].Title)
+		assert.Equal(t, "Stack Overflow", posts[0].Source)
+		assert.Equal(t, 17, posts[0].Score)
+		assert.Equal(t, 3, posts[0].Comments)
+		assert.Equal(t, time.Date(2026, 2, 10, 16, 0, 0, 0, time.UTC), posts[0].PublishedAt)
+	}
+
+	// The fixture asked for a 10 second backoff, so Stack Overflow is not
+	// called again, and with no site left the source reports why.
+	api.Reset()
+	s.Sites = []string{"stackoverflow"}
+	posts, err = s.Search(context.Background(), "type parameter")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "backing off")
+	}
+	assert.Empty(t, posts)
+	assert.Empty(t, api.Calls())
+
+	s.Sites = []string{"serverfault"}
+	_, err = s.Search(context.Background(), "nginx")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "throttle_violation")
+	}
+}
+
+func TestMastodon_Search_BoostsAndCards(t *testing.T) {
+	data := fixture(t, "mastodon_tag_golang.json")
+	api := newFakeAPI(t, files(map[string][]byte{
+		"/a/api/v1/timelines/tag/golang": data,
+		"/b/api/v1/timelines/tag/golang": data,
+	}))
+
+	// Both instances federate the same statuses, and one is down.
+	m := &Mastodon{Client: api.Client(), Instances: []string{api.URL + "/a", api.URL + "/b/", api.URL + "/down"}}
+	posts, err := m.Search(context.Background(), "#Go Lang")
+	assert.NoError(t, err, "one failing instance should not fail the source")
+	if assert.Len(t, posts, 2, "boosts and federated copies are reported once") {
+		assert.Equal(t, "Halving GC pauses in a Go service", posts[0].Title)
+		assert.Equal(t, "https://example.com/gc", posts[0].URL)
+		assert.Equal(t, "https://hachyderm.io/@alice/113000000000000001", posts[0].DiscussionURL)
+		assert.Equal(t, 31, posts[0].Score)
+		assert.Equal(t, 4, posts[0].Comments)
+		assert.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), posts[0].PublishedAt)
+
+		assert.Equal(t, "Is it just me or are range-over-func iterators & generics finally clicking? Loving #golang 1.26", posts[1].Title)
+		assert.Equal(t, "https://hachyderm.io/@carol/113000000000000002", posts[1].URL)
+	}
+
+	m.Instances = []string{api.URL + "/down"}
+	_, err = m.Search(context.Background(), "golang")
+	assert.Error(t, err)
+}
+
+func TestLobsters_Search_TagsAndFallback(t *testing.T) {
+	stories := fixture(t, "lobsters_stories.json")
+	api := newFakeAPI(t, files(map[string][]byte{
+		"/tags.json":      fixture(t, "lobsters_tags.json"),
+		"/t/go.json":      stories,
+		"/t/go,rust.json": stories,
+		"/search.json":    stories,
+		"/newest.json":    stories,
+	}))
+
+	l := &Lobsters{Client: api.Client(), BaseURL: api.URL}
+	posts, err := l.Search(context.Background(), "Go")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 2) {
+		assert.Equal(t, "https://go.dev/blog/alias-names", posts[0].URL)
+		assert.Equal(t, "gopher", posts[0].Author)
+		assert.Equal(t, 42, posts[0].Score)
+		assert.Equal(t, 11, posts[0].Comments)
+		assert.Equal(t, "https://lobste.rs/s/abc123/generic_type_aliases_go_1_24", posts[0].DiscussionURL)
+		assert.Equal(t, time.Date(2026, 2, 12, 15, 15, 0, 0, time.UTC), posts[0].PublishedAt.UTC())
+
+		assert.Equal(t, "tester", posts[1].Author, "older sites send the submitter as an object")
+		assert.Equal(t, posts[1].DiscussionURL, posts[1].URL, "text posts link to their comments")
+	}
+
+	_, err = l.Search(context.Background(), "go rust")
+	assert.NoError(t, err)
+	_, err = l.Search(context.Background(), "go generics")
+	assert.NoError(t, err, "free text falls back to search instead of a 404 tag page")
+	_, err = l.Search(context.Background(), "perl6")
+	assert.NoError(t, err, "inactive tags are searched for")
+
+	assert.Equal(t, []string{
+		"/tags.json",
+		"/t/go.json",
+		"/t/go,rust.json",
+		"/search.json?order=newest&q=go+generics&what=stories",
+		"/search.json?order=newest&q=perl6&what=stories",
+	}, api.Calls(), "the tag list is fetched once")
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "machine-learning", slugify("  Machine   Learning "))
+	assert.Equal(t, "web-assembly", slugify("web_assembly"))
+	assert.Equal(t, "nodejs", slugify("Node.js"))
+	assert.Equal(t, "c-sharp", slugify("c--sharp"))
+	assert.Equal(t, "", slugify("++"))
+}
+
+func TestHashnode_Search_TagAndFeedFallback(t *testing.T) {
+	feed1, feed2 := fixture(t, "hashnode_feed_1.json"), fixture(t, "hashnode_feed_2.json")
+	tag := fixture(t, "hashnode_tag.json")
+	var failPage2 atomic.Bool
+	api := newGraphQLAPI(t, func(w http.ResponseWriter, query string, vars map[string]any) string {
+		switch {
+		case strings.Contains(query, "feed("):
+			if vars["after"] != "cursor-page-2" {
+				w.Write(feed1)
+			} else if failPage2.Load() {
+				w.WriteHeader(http.StatusBadGateway)
+			} else {
+				w.Write(feed2)
+			}
+			return fmt.Sprintf("feed after=%v", vars["after"])
+		default:
+			if vars["slug"] == "webassembly" || vars["slug"] == "go" {
+				w.Write(tag)
+			} else {
+				w.Write([]byte(`{"data": {"tag": null}}`))
+			}
+			return fmt.Sprintf("tag slug=%v", vars["slug"])
+		}
+	})
+	h := &Hashnode{Client: api.Client(), BaseURL: api.URL}
+
+	// The feed and the tag are looked up concurrently, so only the set of
+	// calls each search makes is fixed, not their order.
+	posts, err := h.Search(context.Background(), "Go logging")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 2, "without a tag, free text matches titles, briefs and tags across feed pages") {
+		assert.Equal(t, "Structured logging with slog", posts[0].Title)
+		assert.Equal(t, "alice", posts[0].Author)
+		assert.Equal(t, 12, posts[0].Score)
+		assert.Equal(t, 2, posts[0].Comments)
+		assert.Equal(t, "https://carol.hashnode.dev/logging-pipeline-vector", posts[1].URL)
+	}
+	assert.ElementsMatch(t, []string{"feed after=<nil>", "feed after=cursor-page-2", "tag slug=go-logging"}, api.Calls())
+
+	api.Reset()
+	posts, err = h.Search(context.Background(), "WebAssembly")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1, "queries naming a tag get the tag's posts") {
+		assert.Equal(t, "Getting started with WebAssembly", posts[0].Title)
+	}
+	assert.ElementsMatch(t, []string{"feed after=<nil>", "feed after=cursor-page-2", "tag slug=webassembly"}, api.Calls())
+
+	posts, err = h.Search(context.Background(), "Go")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1, "the tag wins over feed matches") {
+		assert.Equal(t, "Getting started with WebAssembly", posts[0].Title)
+	}
+
+	posts, err = h.Search(context.Background(), "Log")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1, "feed matches are whole words, so log does not match logging") {
+		assert.Equal(t, "Structured logging with slog", posts[0].Title)
+	}
+
+	posts, err = h.Search(context.Background(), "no such topic")
+	assert.NoError(t, err, "an unknown tag is not an error")
+	assert.Empty(t, posts)
+
+	failPage2.Store(true)
+	posts, err = h.Search(context.Background(), "Go logging")
+	assert.NoError(t, err, "a failed later page keeps the posts already found")
+	if assert.Len(t, posts, 1) {
+		assert.Equal(t, "Structured logging with slog", posts[0].Title)
+	}
+}
+
+func TestFreeCodeCamp_Search_TextAndTag(t *testing.T) {
+	search, tagged := fixture(t, "freecodecamp_search.json"), fixture(t, "freecodecamp_tag.json")
+	var down atomic.Bool
+	api := newGraphQLAPI(t, func(w http.ResponseWriter, query string, vars map[string]any) string {
+		switch {
+		case down.Load():
+			w.WriteHeader(http.StatusBadGateway)
+			return "down"
+		case strings.Contains(query, "searchPostsOfPublication"):
+			if vars["q"] == "rest api go" {
+				w.Write(search)
+			} else {
+				w.Write([]byte(`{"data": {"searchPostsOfPublication": {"edges": [], "pageInfo": {"hasNextPage": false}}}}`))
+			}
+			return fmt.Sprintf("search id=%v q=%v", vars["id"], vars["q"])
+		case strings.Contains(query, "posts("):
+			w.Write(tagged)
+			return fmt.Sprintf("posts tags=%v", vars["tags"])
+		default:
+			w.Write([]byte(`{"data": {"publication": {"id": "5f0c1a2b3c4d5e6f70819293"}}}`))
+			return fmt.Sprintf("publication host=%v", vars["host"])
+		}
+	})
+
+	// The search and the tag are looked up concurrently, so only the set of
+	// calls each search makes is fixed, not their order.
+	f := &FreeCodeCamp{Client: api.Client(), BaseURL: api.URL}
+	posts, err := f.Search(context.Background(), "rest api go")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1, "loose search matches missing a word are dropped") {
+		assert.Equal(t, "How to Build a REST API in Go", posts[0].Title)
+		assert.Equal(t, "FreeCodeCamp", posts[0].Source)
+		assert.Equal(t, "gopherdev", posts[0].Author)
+	}
+	assert.ElementsMatch(t, []string{
+		"publication host=freecodecamp.org/news",
+		"search id=5f0c1a2b3c4d5e6f70819293 q=rest api go",
+		"posts tags=[rest-api-go]",
+	}, api.Calls())
+
+	api.Reset()
+	posts, err = f.Search(context.Background(), "Kubernetes")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1, "queries the search misses fall back to the tag") {
+		assert.Equal(t, "Learn Kubernetes in a Weekend", posts[0].Title)
+	}
+	assert.ElementsMatch(t, []string{
+		"search id=5f0c1a2b3c4d5e6f70819293 q=Kubernetes",
+		"posts tags=[kubernetes]",
+	}, api.Calls(), "the publication ID is looked up once")
+
+	down.Store(true)
+	_, err = f.Search(context.Background(), "Kubernetes")
+	if assert.Error(t, err) {
+		assert.Equal(t, 2, strings.Count(err.Error(), "status 502"), "the error covers the search and the tag: %v", err)
+	}
+}
+
+func TestDevTo_Search_Modes(t *testing.T) {
+	api := newFakeAPI(t, files(map[string][]byte{
+		"/api/articles":        fixture(t, "devto_articles.json"),
+		"/search/feed_content": fixture(t, "devto_search.json"),
+	}))
+
+	d := &DevTo{Client: api.Client(), BaseURL: api.URL + "/api", Top: 7, PerPage: 30, Page: 2}
+	posts, err := d.Search(context.Background(), "Go")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1) {
+		assert.Equal(t, "gopher", posts[0].Author)
+		assert.Equal(t, 128, posts[0].Score)
+		assert.Equal(t, 14, posts[0].Comments)
+	}
+
+	posts, err = d.Search(context.Background(), "rate limiter")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1) {
+		assert.Equal(t, api.URL+"/alice/building-a-rate-limiter-in-go-1b3c", posts[0].URL, "links point at the configured host")
+		assert.Equal(t, time.Date(2026, 2, 11, 8, 0, 0, 0, time.UTC), posts[0].PublishedAt)
+		assert.Equal(t, 41, posts[0].Score)
+	}
+
+	// A trailing slash on BaseURL does not double up in request paths.
+	d = &DevTo{Client: api.Client(), BaseURL: api.URL + "/api/", State: "rising"}
+	_, err = d.Search(context.Background(), "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/api/articles?page=2&per_page=30&tag=go&top=7",
+		"/search/feed_content?class_name=Article&page=2&per_page=30&search_fields=rate+limiter&sort_by=published_at&sort_direction=desc",
+		"/api/articles?state=rising",
+	}, api.Calls())
+}
+
+func TestHackerNews_Search(t *testing.T) {
+	data := fixture(t, "hackernews_search.json")
+	api := newFakeAPI(t, func(w http.ResponseWriter, r *http.Request) { w.Write(data) })
+
+	h := &HackerNews{Client: api.Client(), BaseURL: api.URL}
+	posts, err := h.Search(context.Background(), "go")
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 2) {
+		assert.Equal(t, "https://github.com/example/router", posts[0].URL)
+		assert.Equal(t, "https://news.ycombinator.com/item?id=43100001", posts[0].DiscussionURL)
+		assert.Equal(t, "pg_fan", posts[0].Author)
+		assert.Equal(t, 152, posts[0].Score)
+		assert.Equal(t, 48, posts[0].Comments)
+
+		assert.Equal(t, "https://news.ycombinator.com/item?id=43100002", posts[1].URL, "Ask HN posts link to the item")
+	}
+
+	h = &HackerNews{Client: api.Client(), BaseURL: api.URL, ByDate: true, MinPoints: 50, Kind: "show"}
+	_, err = h.Search(context.Background(), "go")
+	assert.NoError(t, err)
+	_, err = h.Search(context.Background(), "Ask HN: go services")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/search?query=go&tags=story",
+		"/search_by_date?numericFilters=points%3E%3D50&query=go&tags=show_hn",
+		"/search_by_date?numericFilters=points%3E%3D50&query=go+services&tags=ask_hn",
+	}, api.Calls())
+}
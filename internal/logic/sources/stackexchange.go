@@ -0,0 +1,174 @@
+package sources
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// StackExchange searches recent questions on Stack Exchange sites through the
+// /search/advanced API, one request per site.
+type StackExchange struct {
+	Client  *http.Client
+	BaseURL string
+	// Sites are API site names such as "stackoverflow" or "serverfault".
+	Sites []string
+	// MinScore drops questions voted below it.
+	MinScore int
+	// Key is an optional app key, raising the daily quota from 300 to 10,000.
+	Key string
+
+	// blocked holds the time each site's backoff ends. The API asks clients
+	// to stop calling a method until then, and throttles those that do not.
+	mu      sync.Mutex
+	blocked map[string]time.Time
+}
+
+func (s *StackExchange) Name() string { return "Stack Exchange" }
+
+// stackExchangeResponse is the common wrapper of every API response.
+type stackExchangeResponse struct {
+	Items []struct {
+		Title        string `json:"title"`
+		Link         string `json:"link"`
+		Score        int    `json:"score"`
+		AnswerCount  int    `json:"answer_count"`
+		CreationDate int64  `json:"creation_date"`
+	} `json:"items"`
+	Backoff      int    `json:"backoff"`
+	ErrorID      int    `json:"error_id"`
+	ErrorName    string `json:"error_name"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// Search returns the newest questions matching query on every site. Sites in
+// backoff are skipped; an error is only returned when every request failed or
+// every site is backing off.
+func (s *StackExchange) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	var searches []func() ([]logic.Post, error)
+	var skipped []string
+	for _, site := range s.Sites {
+		if s.backingOff(site) {
+			skipped = append(skipped, site)
+			continue
+		}
+		searches = append(searches, func() ([]logic.Post, error) {
+			posts, err := s.searchSite(ctx, site, query)
+			if err != nil {
+				return nil, fmt.Errorf("stackexchange %s: %w", site, err)
+			}
+			return posts, nil
+		})
+	}
+	if len(searches) == 0 && len(skipped) > 0 {
+		return nil, fmt.Errorf("stackexchange: backing off from %s", strings.Join(skipped, ", "))
+	}
+	return searchAll(searches)
+}
+
+func (s *StackExchange) backingOff(site string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Now().Before(s.blocked[site])
+}
+
+func (s *StackExchange) backOff(site string, seconds int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.blocked == nil {
+		s.blocked = make(map[string]time.Time)
+	}
+	s.blocked[site] = time.Now().Add(time.Duration(seconds) * time.Second)
+}
+
+func (s *StackExchange) searchSite(ctx context.Context, site, query string) ([]logic.Post, error) {
+	endpoint := s.BaseURL
+	if endpoint == "" {
+		endpoint = "https://api.stackexchange.com/2.3"
+	}
+	params := url.Values{
+		"q":        {query},
+		"site":     {site},
+		"order":    {"desc"},
+		"sort":     {"creation"},
+		"pagesize": {"30"},
+	}
+	if s.Key != "" {
+		params.Set("key", s.Key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"/search/advanced?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "GripAggregator/1.0 (+https://github.com/Numpkens/grip)")
+	// Responses are always compressed. Asking explicitly means the transport
+	// leaves decoding to us, so it also works when the API gzips regardless.
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	var payload stackExchangeResponse
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("api error: status %d", resp.StatusCode)
+		}
+		return nil, err
+	}
+	if payload.Backoff > 0 {
+		s.backOff(site, payload.Backoff)
+	}
+	if payload.ErrorID != 0 {
+		return nil, fmt.Errorf("api error %d %s: %s", payload.ErrorID, payload.ErrorName, payload.ErrorMessage)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("api error: status %d", resp.StatusCode)
+	}
+
+	source := "Stack Exchange"
+	if site == "stackoverflow" {
+		source = "Stack Overflow"
+	}
+
+	var posts []logic.Post
+	for _, q := range payload.Items {
+		if q.Score < s.MinScore {
+			continue
+		}
+		posts = append(posts, logic.Post{
+			// Titles come HTML-escaped ("Can&#39;t ...").
+			Title:         html.UnescapeString(q.Title),
+			URL:           q.Link,
+			Source:        source,
+			PublishedAt:   time.Unix(q.CreationDate, 0).UTC(),
+			Score:         q.Score,
+			Comments:      q.AnswerCount,
+			DiscussionURL: q.Link,
+		})
+	}
+	return posts, nil
+}
@@ -0,0 +1,64 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// Substack reads the RSS feeds of configured publications and matches posts on title.
+type Substack struct {
+	Client *http.Client
+	// Publications are subdomains ("pragmaticengineer"), custom domains
+	// ("newsletter.pragmaticengineer.com") or full URLs.
+	Publications []string
+}
+
+func (s *Substack) Name() string { return "Substack" }
+
+// substackFeed returns the feed URL of a publication entry.
+func substackFeed(pub string) string {
+	pub = strings.TrimSuffix(pub, "/")
+	switch {
+	case strings.Contains(pub, "://"):
+		return pub + "/feed"
+	case strings.Contains(pub, "."):
+		return "https://" + pub + "/feed"
+	default:
+		return "https://" + pub + ".substack.com/feed"
+	}
+}
+
+func (s *Substack) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	if len(s.Publications) == 0 {
+		return nil, nil
+	}
+	urls := make([]string, len(s.Publications))
+	for i, p := range s.Publications {
+		urls[i] = substackFeed(p)
+	}
+
+	feeds, err := fetchFeeds(ctx, s.Client, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	q := strings.ToLower(query)
+	var posts []logic.Post
+	for _, items := range feeds {
+		for _, item := range items {
+			if !strings.Contains(strings.ToLower(item.Title), q) {
+				continue
+			}
+			posts = append(posts, logic.Post{
+				Title:       item.Title,
+				URL:         item.Link,
+				Source:      "Substack",
+				PublishedAt: parseFeedDate(item.Date),
+			})
+		}
+	}
+	return posts, nil
+}
@@ -0,0 +1,198 @@
+// Package notify pushes saved-search matches to outbound webhooks. Each hook
+// gets a generic JSON, Slack or Discord payload, optionally signed with
+// HMAC-SHA256, and failed deliveries are retried with exponential backoff.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+// Headers set on every delivery.
+const (
+	HeaderEvent     = "X-Grip-Event"
+	HeaderDelivery  = "X-Grip-Delivery"
+	HeaderSignature = "X-Grip-Signature"
+)
+
+// EventNewPosts is the only event sent today.
+const EventNewPosts = "saved_search.new_posts"
+
+const (
+	defaultAttempts = 4
+	defaultBackoff  = time.Second
+)
+
+// Notifier delivers matches to a fixed set of webhooks.
+type Notifier struct {
+	Hooks  []config.Webhook
+	Client *http.Client
+	// Log records every delivery; nil disables the log.
+	Log *store.Store
+	// MaxAttempts bounds the requests made per delivery, including the first.
+	MaxAttempts int
+	// Backoff is the wait before the first retry; it doubles on each retry after that.
+	Backoff time.Duration
+}
+
+// New validates hooks and returns a Notifier with the default retry policy.
+func New(hooks []config.Webhook, client *http.Client, deliveryLog *store.Store) (*Notifier, error) {
+	for _, h := range hooks {
+		if _, ok := encoders[h.Format]; !ok {
+			return nil, fmt.Errorf("webhook %q: unknown format %q (want json, slack or discord)", h.Name, h.Format)
+		}
+		u, err := url.Parse(h.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhook %q: url must be an absolute http(s) URL", h.Name)
+		}
+	}
+	return &Notifier{
+		Hooks:       hooks,
+		Client:      client,
+		Log:         deliveryLog,
+		MaxAttempts: defaultAttempts,
+		Backoff:     defaultBackoff,
+	}, nil
+}
+
+// Notify sends posts to every hook subscribed to ss and waits for all of them.
+// Its signature matches watch.Scheduler.OnNew.
+func (n *Notifier) Notify(ctx context.Context, ss store.SavedSearch, posts []logic.Post) {
+	var wg sync.WaitGroup
+	for _, h := range n.Hooks {
+		if len(h.Searches) > 0 && !slices.Contains(h.Searches, ss.Name) {
+			continue
+		}
+		wg.Add(1)
+		go func(h config.Webhook) {
+			defer wg.Done()
+			d := n.Deliver(ctx, h, ss, posts)
+			if !d.OK {
+				log.Printf("notify: %s: delivery %s failed after %d attempt(s): %s", h.Name, d.ID, d.Attempts, d.Error)
+			}
+		}(h)
+	}
+	wg.Wait()
+}
+
+// Deliver sends one payload to h, retrying network errors, 429s and 5xx
+// responses, and records the outcome in the delivery log.
+func (n *Notifier) Deliver(ctx context.Context, h config.Webhook, ss store.SavedSearch, posts []logic.Post) store.Delivery {
+	id, err := newID()
+	d := store.Delivery{
+		ID:      id,
+		Webhook: h.Name,
+		Search:  ss.Name,
+		Posts:   len(posts),
+		SentAt:  time.Now().UTC(),
+	}
+	defer func() {
+		if n.Log == nil {
+			return
+		}
+		if err := n.Log.AddDelivery(d); err != nil {
+			log.Printf("notify: recording delivery %s: %v", d.ID, err)
+		}
+	}()
+	if err != nil {
+		d.Error = err.Error()
+		return d
+	}
+
+	body, err := encoders[h.Format](ss, posts, d.SentAt)
+	if err != nil {
+		d.Error = err.Error()
+		return d
+	}
+
+	backoff := n.Backoff
+	for d.Attempts < max(n.MaxAttempts, 1) {
+		if d.Attempts > 0 {
+			select {
+			case <-ctx.Done():
+				d.Error = ctx.Err().Error()
+				return d
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		d.Attempts++
+
+		retry, err := n.post(ctx, h, d.ID, body, &d.StatusCode)
+		if err == nil {
+			d.OK = true
+			d.Error = ""
+			return d
+		}
+		d.Error = err.Error()
+		if !retry {
+			return d
+		}
+	}
+	return d
+}
+
+// post makes a single request and reports whether a failure is worth retrying.
+func (n *Notifier) post(ctx context.Context, h config.Webhook, id string, body []byte, status *int) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", h.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GripAggregator/1.0 (+https://github.com/Numpkens/grip)")
+	req.Header.Set(HeaderEvent, EventNewPosts)
+	req.Header.Set(HeaderDelivery, id)
+	if h.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(h.Secret, body))
+	}
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	*status = resp.StatusCode
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook error: status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook error: status %d", resp.StatusCode)
+	}
+}
+
+// Sign returns the X-Grip-Signature value for body: "sha256=" followed by the
+// hex HMAC-SHA256 of the raw body under secret. Receivers should recompute it
+// and compare with hmac.Equal.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating delivery ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
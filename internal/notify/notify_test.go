@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+var (
+	testSearch = store.SavedSearch{Name: "golang", Query: "golang"}
+	testPosts  = []logic.Post{{Title: "Go 1.26 <released>", URL: "https://go.dev/blog", Source: "Dev.to", PublishedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}}
+)
+
+func newNotifier(t *testing.T, hooks ...config.Webhook) (*Notifier, *store.Store) {
+	t.Helper()
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := New(hooks, http.DefaultClient, st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.Backoff = time.Millisecond
+	return n, st
+}
+
+func TestDeliverSignsGenericPayload(t *testing.T) {
+	var got Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if want := Sign("s3cret", body); !hmac.Equal([]byte(r.Header.Get(HeaderSignature)), []byte(want)) {
+			t.Errorf("bad signature %q, want %q", r.Header.Get(HeaderSignature), want)
+		}
+		if r.Header.Get(HeaderEvent) != EventNewPosts || r.Header.Get(HeaderDelivery) == "" {
+			t.Errorf("missing event headers: %v", r.Header)
+		}
+		json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+
+	n, _ := newNotifier(t, config.Webhook{Name: "hook", URL: srv.URL, Secret: "s3cret"})
+	d := n.Deliver(context.Background(), n.Hooks[0], testSearch, testPosts)
+
+	if !d.OK || d.Attempts != 1 || d.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected delivery: %+v", d)
+	}
+	if got.Search.Name != "golang" || len(got.Posts) != 1 || got.Posts[0].URL != "https://go.dev/blog" {
+		t.Errorf("unexpected payload: %+v", got)
+	}
+}
+
+func TestDeliverRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	n, st := newNotifier(t, config.Webhook{Name: "flaky", URL: srv.URL})
+	n.Notify(context.Background(), testSearch, testPosts)
+
+	log, err := st.Deliveries(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log) != 1 || !log[0].OK || log[0].Attempts != 3 || log[0].Webhook != "flaky" {
+		t.Fatalf("expected one successful delivery after 3 attempts, got %+v", log)
+	}
+}
+
+func TestDeliverDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	n, _ := newNotifier(t, config.Webhook{Name: "gone", URL: srv.URL})
+	d := n.Deliver(context.Background(), n.Hooks[0], testSearch, testPosts)
+
+	if d.OK || d.Attempts != 1 || d.StatusCode != http.StatusNotFound || calls.Load() != 1 {
+		t.Errorf("expected a single failed attempt, got %+v after %d calls", d, calls.Load())
+	}
+}
+
+func TestNotifyFiltersBySearch(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls.Add(1) }))
+	defer srv.Close()
+
+	n, _ := newNotifier(t,
+		config.Webhook{Name: "all", URL: srv.URL},
+		config.Webhook{Name: "htmx-only", URL: srv.URL, Searches: []string{"htmx"}},
+	)
+	n.Notify(context.Background(), testSearch, testPosts)
+
+	if calls.Load() != 1 {
+		t.Errorf("expected only the unfiltered hook to fire, got %d calls", calls.Load())
+	}
+}
+
+func TestChatPayloads(t *testing.T) {
+	slack, err := encodeSlack(testSearch, testPosts, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Text struct{ Text string } `json:"text"`
+		} `json:"blocks"`
+	}
+	json.Unmarshal(slack, &s)
+	if s.Text == "" || len(s.Blocks) != 2 || s.Blocks[1].Text.Text != "<https://go.dev/blog|Go 1.26 &lt;released&gt;>\n_Dev.to_" {
+		t.Errorf("unexpected Slack payload: %s", slack)
+	}
+
+	discord, err := encodeDiscord(testSearch, testPosts, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d struct {
+		Content string `json:"content"`
+		Embeds  []struct {
+			Title     string `json:"title"`
+			URL       string `json:"url"`
+			Timestamp string `json:"timestamp"`
+		} `json:"embeds"`
+	}
+	json.Unmarshal(discord, &d)
+	if d.Content == "" || len(d.Embeds) != 1 || d.Embeds[0].URL != "https://go.dev/blog" || d.Embeds[0].Timestamp != "2026-02-01T00:00:00Z" {
+		t.Errorf("unexpected Discord payload: %s", discord)
+	}
+}
+
+func TestNewRejectsBadHooks(t *testing.T) {
+	bad := []config.Webhook{
+		{Name: "format", URL: "https://example.com", Format: "teams"},
+		{Name: "url", URL: "example.com/hook"},
+	}
+	for _, h := range bad {
+		if _, err := New([]config.Webhook{h}, http.DefaultClient, nil); err == nil {
+			t.Errorf("%s: expected an error", h.Name)
+		}
+	}
+}
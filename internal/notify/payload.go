@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+// encoders maps config.Webhook.Format to its payload builder. The empty
+// format is the generic JSON payload.
+var encoders = map[string]func(store.SavedSearch, []logic.Post, time.Time) ([]byte, error){
+	"":        encodeJSON,
+	"json":    encodeJSON,
+	"slack":   encodeSlack,
+	"discord": encodeDiscord,
+}
+
+// Payload is the generic JSON body.
+type Payload struct {
+	Event  string       `json:"event"`
+	Search PayloadQuery `json:"search"`
+	Posts  []logic.Post `json:"posts"`
+	SentAt time.Time    `json:"sent_at"`
+}
+
+// PayloadQuery identifies the saved search that matched.
+type PayloadQuery struct {
+	Name    string   `json:"name"`
+	Query   string   `json:"query"`
+	Sources []string `json:"sources,omitempty"`
+}
+
+func encodeJSON(ss store.SavedSearch, posts []logic.Post, now time.Time) ([]byte, error) {
+	return json.Marshal(Payload{
+		Event:  EventNewPosts,
+		Search: PayloadQuery{Name: ss.Name, Query: ss.Query, Sources: ss.Sources},
+		Posts:  posts,
+		SentAt: now,
+	})
+}
+
+func summary(ss store.SavedSearch, posts []logic.Post) string {
+	return fmt.Sprintf("%d new post(s) for saved search %q", len(posts), ss.Name)
+}
+
+// slackEscaper escapes the three characters Slack's mrkdwn treats as control characters.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// encodeSlack builds an incoming-webhook message with one section per post.
+func encodeSlack(ss store.SavedSearch, posts []logic.Post, now time.Time) ([]byte, error) {
+	type text struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	type block struct {
+		Type string `json:"type"`
+		Text *text  `json:"text,omitempty"`
+	}
+
+	blocks := []block{{Type: "header", Text: &text{Type: "plain_text", Text: summary(ss, posts)}}}
+	for _, p := range posts {
+		line := fmt.Sprintf("<%s|%s>\n_%s_", p.URL, slackEscaper.Replace(p.Title), slackEscaper.Replace(p.Source))
+		blocks = append(blocks, block{Type: "section", Text: &text{Type: "mrkdwn", Text: line}})
+	}
+	// Slack rejects messages with more than 50 blocks.
+	if len(blocks) > 50 {
+		blocks = blocks[:50]
+	}
+
+	return json.Marshal(struct {
+		Text   string  `json:"text"`
+		Blocks []block `json:"blocks"`
+	}{Text: summary(ss, posts), Blocks: blocks})
+}
+
+// encodeDiscord builds an execute-webhook message with one embed per post.
+func encodeDiscord(ss store.SavedSearch, posts []logic.Post, now time.Time) ([]byte, error) {
+	type footer struct {
+		Text string `json:"text"`
+	}
+	type embed struct {
+		Title     string  `json:"title"`
+		URL       string  `json:"url"`
+		Timestamp string  `json:"timestamp,omitempty"`
+		Footer    *footer `json:"footer,omitempty"`
+	}
+
+	var embeds []embed
+	for _, p := range posts {
+		e := embed{Title: truncate(p.Title, 256), URL: p.URL, Footer: &footer{Text: p.Source}}
+		if !p.PublishedAt.IsZero() {
+			e.Timestamp = p.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		embeds = append(embeds, e)
+	}
+	// Discord allows at most 10 embeds per message.
+	if len(embeds) > 10 {
+		embeds = embeds[:10]
+	}
+
+	return json.Marshal(struct {
+		Username string  `json:"username"`
+		Content  string  `json:"content"`
+		Embeds   []embed `json:"embeds"`
+	}{Username: "GRIP", Content: summary(ss, posts), Embeds: embeds})
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
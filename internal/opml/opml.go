@@ -0,0 +1,143 @@
+// Package opml converts between OPML subscription lists, as exported by most
+// feed readers, and the feed sources in the GRIP config.
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// Document is an OPML 2.0 file.
+type Document struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    Head     `xml:"head"`
+	Body    Body     `xml:"body"`
+}
+
+type Head struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+type Body struct {
+	Outlines []Outline `xml:"outline"`
+}
+
+// Outline is either a folder of nested outlines or a single subscription.
+type Outline struct {
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr,omitempty"`
+	Type     string    `xml:"type,attr,omitempty"`
+	XMLURL   string    `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string    `xml:"htmlUrl,attr,omitempty"`
+	Outlines []Outline `xml:"outline"`
+}
+
+// Parse decodes an OPML document.
+func Parse(r io.Reader) (*Document, error) {
+	var doc Document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Write encodes doc with an XML header.
+func Write(w io.Writer, doc *Document) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Feeds flattens every subscription in doc, including those nested in folders,
+// into config feed entries. Outlines without an xmlUrl are skipped.
+func (doc *Document) Feeds() []config.Feed {
+	var feeds []config.Feed
+	var walk func([]Outline)
+	walk = func(outlines []Outline) {
+		for _, o := range outlines {
+			if url := strings.TrimSpace(o.XMLURL); url != "" {
+				name := o.Title
+				if name == "" {
+					name = o.Text
+				}
+				if name == "" {
+					name = url
+				}
+				feeds = append(feeds, config.Feed{Name: name, URL: url, Site: o.HTMLURL})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+	return feeds
+}
+
+// FromFeeds builds a flat OPML document listing feeds.
+func FromFeeds(title string, feeds []config.Feed) *Document {
+	doc := &Document{
+		Version: "2.0",
+		Head: Head{
+			Title:       title,
+			DateCreated: time.Now().UTC().Format(time.RFC1123Z),
+		},
+	}
+	for _, f := range feeds {
+		doc.Body.Outlines = append(doc.Body.Outlines, Outline{
+			Text:    f.Name,
+			Title:   f.Name,
+			Type:    "rss",
+			XMLURL:  f.URL,
+			HTMLURL: f.Site,
+		})
+	}
+	return doc
+}
+
+// Merge appends the feeds in incoming that existing does not already have,
+// matching on URL, and returns the combined list and how many were added.
+// A new feed whose name clashes with one already listed gets a numbered
+// name, since config.Validate rejects feeds that share a name.
+func Merge(existing, incoming []config.Feed) ([]config.Feed, int) {
+	seen := make(map[string]bool, len(existing))
+	names := make(map[string]bool, len(existing))
+	for _, f := range existing {
+		seen[f.URL] = true
+		names[logic.SourceKey(f.Name)] = true
+	}
+
+	merged := existing
+	added := 0
+	for _, f := range incoming {
+		if seen[f.URL] {
+			continue
+		}
+		seen[f.URL] = true
+		if logic.SourceKey(f.Name) == "" {
+			f.Name = f.URL
+		}
+		name := f.Name
+		for n := 2; names[logic.SourceKey(name)]; n++ {
+			name = fmt.Sprintf("%s (%d)", f.Name, n)
+		}
+		f.Name = name
+		names[logic.SourceKey(name)] = true
+		merged = append(merged, f)
+		added++
+	}
+	return merged, added
+}
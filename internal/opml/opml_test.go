@@ -0,0 +1,100 @@
+package opml
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Numpkens/grip/internal/config"
+)
+
+func parseFile(t *testing.T, name string) *Document {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	doc, err := Parse(f)
+	if err != nil {
+		t.Fatalf("parse %s: %v", name, err)
+	}
+	return doc
+}
+
+func TestFeedsFlattensFolders(t *testing.T) {
+	feeds := parseFile(t, "reader-export.opml").Feeds()
+
+	want := []config.Feed{
+		{Name: "The Go Blog", URL: "https://go.dev/blog/feed.atom", Site: "https://go.dev/blog"},
+		{Name: "Dave Cheney", URL: "https://dave.cheney.net/feed", Site: "https://dave.cheney.net"},
+		{Name: "SQLite News", URL: "https://sqlite.org/news.rss"},
+		{Name: "Julia Evans", URL: "https://jvns.ca/atom.xml", Site: "https://jvns.ca"},
+	}
+	if !reflect.DeepEqual(feeds, want) {
+		t.Errorf("unexpected feeds:\n got %+v\nwant %+v", feeds, want)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, name := range []string{"reader-export.opml", "flat.opml"} {
+		t.Run(name, func(t *testing.T) {
+			feeds := parseFile(t, name).Feeds()
+
+			var buf bytes.Buffer
+			if err := Write(&buf, FromFeeds("GRIP sources", feeds)); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+
+			doc, err := Parse(&buf)
+			if err != nil {
+				t.Fatalf("re-parse: %v", err)
+			}
+			if doc.Version != "2.0" || doc.Head.Title != "GRIP sources" {
+				t.Errorf("unexpected head: %+v", doc.Head)
+			}
+			if got := doc.Feeds(); !reflect.DeepEqual(got, feeds) {
+				t.Errorf("round trip changed feeds:\n got %+v\nwant %+v", got, feeds)
+			}
+		})
+	}
+}
+
+func TestMergeSkipsDuplicates(t *testing.T) {
+	existing := []config.Feed{{Name: "Go", URL: "https://go.dev/blog/feed.atom"}}
+	incoming := parseFile(t, "reader-export.opml").Feeds()
+
+	merged, added := Merge(existing, incoming)
+	if added != 3 || len(merged) != 4 {
+		t.Errorf("expected 3 new feeds (4 total), got %d added, %d total", added, len(merged))
+	}
+	if merged[0].Name != "Go" {
+		t.Errorf("expected existing entries to be kept as-is, got %+v", merged[0])
+	}
+}
+
+func TestMergeRenamesClashingNames(t *testing.T) {
+	existing := []config.Feed{{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom"}}
+	incoming := []config.Feed{
+		{Name: "go-blog", URL: "https://example.com/go/feed"},
+		{Name: "Go Blog", URL: "https://mirror.example.com/feed"},
+		{Name: "***", URL: "https://example.com/stars"},
+	}
+
+	merged, _ := Merge(existing, incoming)
+	var names []string
+	for _, f := range merged {
+		names = append(names, f.Name)
+	}
+	want := []string{"Go Blog", "go-blog (2)", "Go Blog (3)", "https://example.com/stars"}
+	if !slices.Equal(names, want) {
+		t.Errorf("expected names %q, got %q", want, names)
+	}
+	if err := (config.Config{Sources: config.Sources{Feeds: merged}}).Validate(); err != nil {
+		t.Errorf("expected merged feeds to validate, got %v", err)
+	}
+}
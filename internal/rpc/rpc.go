@@ -0,0 +1,175 @@
+// Package rpc serves the GRIP engine over gRPC using the SearchService
+// defined in proto/grip/v1/search.proto.
+package rpc
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/Numpkens/grip --go-grpc_out=../.. --go-grpc_opt=module=github.com/Numpkens/grip grip/v1/search.proto
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/rpc/grippb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// APIVersion is reported in every response, matching the REST API.
+const APIVersion = "v1"
+
+// Server implements grippb.SearchServiceServer on top of a logic.Engine.
+type Server struct {
+	grippb.UnimplementedSearchServiceServer
+	Engine *logic.Engine
+}
+
+// NewGRPCServer returns a grpc.Server with the search service, the standard
+// health service and server reflection registered.
+func NewGRPCServer(engine *logic.Engine, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(opts...)
+	grippb.RegisterSearchServiceServer(srv, &Server{Engine: engine})
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthSrv.SetServingStatus(grippb.SearchService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	reflection.Register(srv)
+	return srv
+}
+
+// Search implements the unary SearchService.Search RPC.
+func (s *Server) Search(ctx context.Context, req *grippb.SearchRequest) (*grippb.SearchResponse, error) {
+	start := time.Now()
+	query, res, err := s.search(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grippb.SearchResponse{
+		ApiVersion: APIVersion,
+		Query:      query,
+		Results:    toPosts(res.Posts),
+		NextCursor: res.NextCursor,
+		Sources:    toStatuses(res.Sources),
+		LatencyMs:  time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// StreamSearch implements the server-streaming SearchService.StreamSearch RPC.
+// Each source's posts are sent as soon as that source answers, so fast sources
+// are not held up by slow ones.
+func (s *Server) StreamSearch(req *grippb.SearchRequest, stream grpc.ServerStreamingServer[grippb.StreamSearchResponse]) error {
+	start := time.Now()
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// A failed send means the client is gone, so the search is abandoned.
+	var sendErr error
+	query, res, err := s.search(ctx, req, func(_ logic.SourceStatus, posts []logic.Post) {
+		for _, p := range posts {
+			if sendErr != nil {
+				return
+			}
+			sendErr = stream.Send(&grippb.StreamSearchResponse{
+				Event: &grippb.StreamSearchResponse_Post{Post: toPost(p)},
+			})
+			if sendErr != nil {
+				cancel()
+			}
+		}
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&grippb.StreamSearchResponse{
+		Event: &grippb.StreamSearchResponse_Summary{Summary: &grippb.SearchSummary{
+			ApiVersion: APIVersion,
+			Query:      query,
+			NextCursor: res.NextCursor,
+			Sources:    toStatuses(res.Sources),
+			LatencyMs:  time.Since(start).Milliseconds(),
+		}},
+	})
+}
+
+// search validates req, runs it against the engine and maps failures onto gRPC
+// codes. onSource, when set, receives each source's posts as it answers.
+func (s *Server) search(ctx context.Context, req *grippb.SearchRequest, onSource func(logic.SourceStatus, []logic.Post)) (string, logic.SearchResult, error) {
+	opts := logic.SearchOptions{
+		Query:    req.GetQuery(),
+		Limit:    int(req.GetLimit()),
+		After:    req.GetAfter(),
+		Sources:  req.GetSources(),
+		OnSource: onSource,
+	}
+	if opts.Query == "" {
+		opts.Query = "golang"
+	}
+	if err := logic.ValidateQuery(opts.Query); err != nil {
+		return "", logic.SearchResult{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if opts.Limit < 0 || opts.Limit > logic.MaxLimit {
+		return "", logic.SearchResult{}, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", logic.MaxLimit)
+	}
+	if req.GetSince() != nil {
+		opts.Since = req.GetSince().AsTime()
+	}
+
+	res, err := s.Engine.Search(ctx, opts)
+	var unknown *logic.UnknownSourceError
+	switch {
+	case errors.As(err, &unknown), errors.Is(err, logic.ErrInvalidCursor):
+		return "", res, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return "", res, status.Error(codes.Internal, err.Error())
+	case res.AllFailed():
+		return "", res, status.Error(codes.Unavailable, "every source failed or timed out")
+	}
+	return opts.Query, res, nil
+}
+
+func toPost(p logic.Post) *grippb.Post {
+	return &grippb.Post{
+		Title:         p.Title,
+		Url:           p.URL,
+		Source:        p.Source,
+		PublishedAt:   timestamppb.New(p.PublishedAt),
+		Author:        p.Author,
+		Score:         int32(p.Score),
+		Comments:      int32(p.Comments),
+		DiscussionUrl: p.DiscussionURL,
+	}
+}
+
+func toPosts(posts []logic.Post) []*grippb.Post {
+	out := make([]*grippb.Post, len(posts))
+	for i, p := range posts {
+		out[i] = toPost(p)
+	}
+	return out
+}
+
+func toStatuses(statuses []logic.SourceStatus) []*grippb.SourceStatus {
+	out := make([]*grippb.SourceStatus, len(statuses))
+	for i, s := range statuses {
+		out[i] = &grippb.SourceStatus{
+			Name:      s.Name,
+			Status:    s.Status,
+			Count:     int32(s.Count),
+			Error:     s.Error,
+			LatencyMs: s.LatencyMS,
+		}
+	}
+	return out
+}
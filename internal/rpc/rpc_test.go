@@ -0,0 +1,208 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/rpc/grippb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeSource struct {
+	name  string
+	posts []logic.Post
+	err   error
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func (f *fakeSource) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	return f.posts, f.err
+}
+
+// slowSource answers once release is closed.
+type slowSource struct {
+	fakeSource
+	release chan struct{}
+}
+
+func (s *slowSource) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	select {
+	case <-s.release:
+		return s.posts, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func dial(t *testing.T, engine *logic.Engine) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := NewGRPCServer(engine)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func testEngine() *logic.Engine {
+	now := time.Now()
+	return logic.NewEngine([]logic.Source{
+		&fakeSource{name: "Fake", posts: []logic.Post{
+			{Title: "New", URL: "https://example.com/new", PublishedAt: now, Author: "gopher", Score: 42, Comments: 7, DiscussionURL: "https://example.com/new#comments"},
+			{Title: "Old", URL: "https://example.com/old", PublishedAt: now.Add(-time.Hour)},
+		}},
+		&fakeSource{name: "Broken", err: errors.New("boom")},
+	})
+}
+
+func TestSearch(t *testing.T) {
+	client := grippb.NewSearchServiceClient(dial(t, testEngine()))
+
+	resp, err := client.Search(context.Background(), &grippb.SearchRequest{Query: "go", Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Title != "New" {
+		t.Errorf("unexpected results: %v", resp.Results)
+	} else if p := resp.Results[0]; p.Author != "gopher" || p.Score != 42 || p.Comments != 7 || p.DiscussionUrl != "https://example.com/new#comments" {
+		t.Errorf("expected author and discussion fields, got %v", p)
+	}
+	if resp.NextCursor == "" {
+		t.Error("expected a next cursor")
+	}
+	if len(resp.Sources) != 2 || resp.Sources[1].Status != logic.StatusError {
+		t.Errorf("unexpected source statuses: %v", resp.Sources)
+	}
+}
+
+func TestStreamSearch(t *testing.T) {
+	client := grippb.NewSearchServiceClient(dial(t, testEngine()))
+
+	stream, err := client.StreamSearch(context.Background(), &grippb.SearchRequest{Query: "go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var posts int
+	var summary *grippb.SearchSummary
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("recv: %v", err)
+		}
+		if p := msg.GetPost(); p != nil {
+			posts++
+		}
+		if s := msg.GetSummary(); s != nil {
+			summary = s
+		}
+	}
+
+	if posts != 2 {
+		t.Errorf("expected 2 streamed posts, got %d", posts)
+	}
+	if summary == nil || summary.Query != "go" || len(summary.Sources) != 2 {
+		t.Errorf("expected a trailing summary, got %v", summary)
+	}
+}
+
+func TestStreamSearch_SendsEachSourceAsItAnswers(t *testing.T) {
+	now := time.Now()
+	slow := &slowSource{
+		fakeSource: fakeSource{name: "Slow", posts: []logic.Post{{Title: "Late", URL: "https://example.com/late", PublishedAt: now}}},
+		release:    make(chan struct{}),
+	}
+	engine := logic.NewEngine([]logic.Source{
+		&fakeSource{name: "Fast", posts: []logic.Post{{Title: "Early", URL: "https://example.com/early", PublishedAt: now.Add(-time.Hour)}}},
+		slow,
+	})
+	client := grippb.NewSearchServiceClient(dial(t, engine))
+
+	stream, err := client.StreamSearch(context.Background(), &grippb.SearchRequest{Query: "go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The fast source's post arrives while the slow source is still searching.
+	msg, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if p := msg.GetPost(); p == nil || p.Title != "Early" {
+		t.Fatalf("expected the fast source's post first, got %v", msg)
+	}
+	close(slow.release)
+
+	msg, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if p := msg.GetPost(); p == nil || p.Title != "Late" {
+		t.Fatalf("expected the slow source's post next, got %v", msg)
+	}
+	msg, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if s := msg.GetSummary(); s == nil || len(s.Sources) != 2 {
+		t.Errorf("expected a trailing summary, got %v", msg)
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	broken := logic.NewEngine([]logic.Source{&fakeSource{name: "Broken", err: errors.New("boom")}})
+	client := grippb.NewSearchServiceClient(dial(t, broken))
+
+	tests := []struct {
+		name string
+		req  *grippb.SearchRequest
+		want codes.Code
+	}{
+		{"unknown source", &grippb.SearchRequest{Sources: []string{"myspace"}}, codes.InvalidArgument},
+		{"bad limit", &grippb.SearchRequest{Limit: 500}, codes.InvalidArgument},
+		{"all failed", &grippb.SearchRequest{Query: "go"}, codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Search(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("expected %v, got %v (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	client := healthpb.NewHealthClient(dial(t, testEngine()))
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "grip.v1.SearchService"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("expected SERVING, got %v", resp.Status)
+	}
+}
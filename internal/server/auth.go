@@ -0,0 +1,153 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/handlers"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+// keyRefresh is how often the authenticator reloads keys from the store and
+// flushes usage counters. It bounds how long a revoked key keeps working and
+// how many counted requests a restart can lose.
+const keyRefresh = 10 * time.Second
+
+// authenticator checks API keys against the store and applies per-key limits.
+type authenticator struct {
+	cfg     config.Auth
+	store   *store.Store
+	limiter *rateLimiter
+
+	mu       sync.Mutex
+	keys     map[string]store.APIKey // by hash
+	loadedAt time.Time
+	usage    map[string]store.KeyUsage // by key ID, not yet flushed
+}
+
+func newAuthenticator(cfg config.Auth, st *store.Store) *authenticator {
+	return &authenticator{
+		cfg:     cfg,
+		store:   st,
+		limiter: newRateLimiter(cfg.KeyRateLimit, false),
+		usage:   make(map[string]store.KeyUsage),
+	}
+}
+
+// middleware authenticates requests that carry a key and sends the rest to
+// anonymous, or rejects them when anonymous access is off.
+func (a *authenticator) middleware(anonymous func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		anon := anonymous(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret := apiKey(r)
+			if secret == "" {
+				if !a.cfg.AllowAnonymous {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="grip"`)
+					handlers.WriteProblem(w, r, http.StatusUnauthorized, handlers.CodeUnauthorized,
+						"an API key is required: send it as a Bearer token or in X-API-Key")
+					return
+				}
+				anon.ServeHTTP(w, r)
+				return
+			}
+
+			now := time.Now()
+			key, ok := a.lookup(secret, now)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="grip", error="invalid_token"`)
+				handlers.WriteProblem(w, r, http.StatusUnauthorized, handlers.CodeUnauthorized, "the API key is invalid or revoked")
+				return
+			}
+
+			rate, burst := a.limiter.rate, a.limiter.burst
+			if key.RequestsPerMinute > 0 {
+				rate = float64(key.RequestsPerMinute) / 60
+			}
+			if rate > 0 {
+				if allowed, wait := a.limiter.allowRate(key.ID, rate, burst, now); !allowed {
+					writeRateLimited(w, r, wait)
+					return
+				}
+			}
+
+			a.record(key.ID, now)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireKey rejects requests that carry no API key. It runs behind the
+// authenticator, which has already turned away invalid ones.
+func requireKey(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if apiKey(r) == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="grip"`)
+			handlers.WriteProblem(w, r, http.StatusUnauthorized, handlers.CodeUnauthorized,
+				"an API key is required to make changes")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// apiKey reads the key from "Authorization: Bearer" or X-API-Key.
+func apiKey(r *http.Request) string {
+	if scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return strings.TrimSpace(r.Header.Get("X-API-Key"))
+}
+
+// lookup returns the active key matching secret, refreshing the cache when it is stale.
+func (a *authenticator) lookup(secret string, now time.Time) (store.APIKey, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.keys == nil || now.Sub(a.loadedAt) > keyRefresh {
+		a.refresh(now)
+	}
+	key, ok := a.keys[store.HashKey(secret)]
+	if !ok || key.Revoked() {
+		return store.APIKey{}, false
+	}
+	return key, true
+}
+
+// refresh flushes pending usage and reloads the keys. On a store error the
+// previous keys stay in use so a bad write does not lock every client out.
+func (a *authenticator) refresh(now time.Time) {
+	if err := a.store.AddKeyUsage(a.usage); err != nil {
+		log.Printf("auth: flushing key usage: %v", err)
+	} else {
+		a.usage = make(map[string]store.KeyUsage)
+	}
+
+	keys, err := a.store.Keys()
+	if err != nil {
+		log.Printf("auth: loading keys: %v", err)
+		if a.keys == nil {
+			a.keys = make(map[string]store.APIKey)
+		}
+		a.loadedAt = now
+		return
+	}
+	a.keys = make(map[string]store.APIKey, len(keys))
+	for _, k := range keys {
+		a.keys[k.Hash] = k
+	}
+	a.loadedAt = now
+}
+
+func (a *authenticator) record(id string, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	u := a.usage[id]
+	u.Requests++
+	u.LastUsedAt = now.UTC()
+	a.usage[id] = u
+}
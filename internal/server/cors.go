@@ -0,0 +1,68 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+)
+
+// newCORS returns middleware applying cfg to cross-origin requests and
+// answering preflights itself. It must wrap auth and rate limiting, since
+// browsers send preflights without credentials.
+func newCORS(cfg config.CORS) (func(http.Handler) http.Handler, error) {
+	if len(cfg.AllowedOrigins) == 0 {
+		return func(next http.Handler) http.Handler { return next }, nil
+	}
+
+	anyOrigin := slices.Contains(cfg.AllowedOrigins, "*")
+	if anyOrigin && cfg.AllowCredentials {
+		return nil, errors.New(`server.cors: allow_credentials cannot be combined with the "*" origin`)
+	}
+
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	exposed := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(time.Duration(cfg.MaxAge).Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			h := w.Header()
+			if !anyOrigin {
+				// The response depends on Origin, so shared caches must key on it.
+				h.Add("Vary", "Origin")
+			}
+			if origin == "" || !(anyOrigin || slices.Contains(cfg.AllowedOrigins, origin)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if anyOrigin {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			if cfg.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Set("Access-Control-Allow-Methods", methods)
+				h.Set("Access-Control-Allow-Headers", headers)
+				h.Set("Access-Control-Max-Age", maxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if exposed != "" {
+				h.Set("Access-Control-Expose-Headers", exposed)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/logic/sources"
+	"github.com/Numpkens/grip/internal/notify"
+	"github.com/Numpkens/grip/internal/store"
+	"github.com/Numpkens/grip/internal/watch"
+)
+
+// Main is the shared entry point for the GRIP server binaries. Each binary
+// passes its own defaults, which the config file and flags can then override.
+func Main(defaults config.Config) {
+	configPath := flag.String("config", config.PathFromEnv(), "path to the JSON config file (env GRIP_CONFIG)")
+	addr := flag.String("addr", "", "listen address, overrides the config (env GRIP_ADDR or PORT)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath, defaults)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *addr != "" {
+		cfg.Server.Addr = *addr
+	}
+
+	engine := logic.NewEngine(sources.FromConfig(cfg.Sources, sources.NewHTTPClient()))
+
+	st, err := store.Open(cfg.Store.Path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	router, err := NewRouter(cfg.Server, engine, st)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The scheduler shares the server's lifetime so a signal stops both.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if interval := time.Duration(cfg.Watch.Interval); interval > 0 {
+		scheduler := &watch.Scheduler{Engine: engine, Store: st, Interval: interval}
+		if len(cfg.Webhooks) > 0 {
+			notifier, err := notify.New(cfg.Webhooks, &http.Client{Timeout: 10 * time.Second}, st)
+			if err != nil {
+				log.Fatal(err)
+			}
+			scheduler.OnNew = notifier.Notify
+		}
+		go scheduler.Run(ctx)
+		log.Printf("Polling saved searches every %s", interval)
+	}
+
+	if err := Run(ctx, New(cfg.Server.Addr, router)); err != nil {
+		log.Fatal(err)
+	}
+}
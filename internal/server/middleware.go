@@ -0,0 +1,156 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/handlers"
+)
+
+// problemFallback turns the mux's own plain-text 404 and 405 replies into
+// problem+json so that every API path answers with the same error shape.
+func problemFallback(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := mux.Handler(r); pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+		mux.ServeHTTP(&problemWriter{ResponseWriter: w, r: r}, r)
+	})
+}
+
+// problemWriter swaps the body of mux-generated errors for a Problem.
+type problemWriter struct {
+	http.ResponseWriter
+	r        *http.Request
+	replaced bool
+}
+
+func (pw *problemWriter) WriteHeader(code int) {
+	switch code {
+	case http.StatusNotFound:
+		pw.replaced = true
+		handlers.WriteProblem(pw.ResponseWriter, pw.r, code, handlers.CodeNotFound, "no API route matches "+pw.r.URL.Path)
+	case http.StatusMethodNotAllowed:
+		pw.replaced = true
+		handlers.WriteProblem(pw.ResponseWriter, pw.r, code, handlers.CodeMethodNotAllowed, pw.r.Method+" is not supported on "+pw.r.URL.Path)
+	default:
+		pw.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (pw *problemWriter) Write(b []byte) (int, error) {
+	if pw.replaced {
+		return len(b), nil
+	}
+	return pw.ResponseWriter.Write(b)
+}
+
+// rateLimiter is a per-client token bucket.
+type rateLimiter struct {
+	rate       float64 // tokens per second
+	burst      float64
+	trustProxy bool
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(cfg config.RateLimit, trustProxy bool) *rateLimiter {
+	burst := cfg.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:       float64(cfg.RequestsPerMinute) / 60,
+		burst:      float64(burst),
+		trustProxy: trustProxy,
+		buckets:    make(map[string]*bucket),
+		lastSweep:  time.Now(),
+	}
+}
+
+// allow takes a token for key, returning how long to wait when none is left.
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	return l.allowRate(key, l.rate, l.burst, now)
+}
+
+// allowRate is allow with a bucket size and refill rate specific to key.
+func (l *rateLimiter) allowRate(key string, rate, burst float64, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Forget idle clients once a minute so the map cannot grow without bound.
+	if now.Sub(l.lastSweep) > time.Minute {
+		for k, b := range l.buckets {
+			if now.Sub(b.last) > 10*time.Minute {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// middleware rejects clients that exhausted their bucket with a rate_limited problem.
+func (l *rateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, wait := l.allow(clientIP(r, l.trustProxy), time.Now())
+		if !ok {
+			writeRateLimited(w, r, wait)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeRateLimited sends a rate_limited problem telling the client how long to wait.
+func writeRateLimited(w http.ResponseWriter, r *http.Request, wait time.Duration) {
+	retry := int(math.Ceil(wait.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(retry))
+	handlers.WriteProblem(w, r, http.StatusTooManyRequests, handlers.CodeRateLimited,
+		fmt.Sprintf("too many requests, retry in %d seconds", retry))
+}
+
+// clientIP identifies the caller for rate limiting.
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			first, _, _ := strings.Cut(fwd, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
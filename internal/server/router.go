@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	_ "github.com/Numpkens/grip/docs"
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/gql"
+	"github.com/Numpkens/grip/internal/handlers"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+	httpSwagger "github.com/swaggo/http-swagger"
+)
+
+// NewRouter mounts the route groups enabled in cfg.Features onto a fresh mux.
+// The saved-search, bookmark and history routes are only mounted when st is
+// non-nil, and the UI only offers bookmarks and read marks when they are.
+//
+// @title           GRIP API
+// @version         1.0
+// @description     A high-performance developer blog aggregator proxy.
+// @host            localhost:8080
+// @BasePath        /
+//
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Required only when server.auth is enabled; "Authorization: Bearer <key>" also works.
+func NewRouter(cfg config.Server, engine *logic.Engine, st *store.Store) (http.Handler, error) {
+	h := &handlers.Handler{Engine: engine, TrustProxy: cfg.TrustProxy}
+	if cfg.Features.API {
+		h.Store = st
+	}
+
+	if cfg.Features.UI {
+		tmpl, err := template.ParseFiles(cfg.TemplatePath)
+		if err != nil {
+			return nil, err
+		}
+		h.Templ = tmpl
+	}
+
+	// One limiter is shared by every route that runs a search, so a client
+	// cannot double its budget by alternating between "/", REST, GraphQL and
+	// the feeds.
+	limit := func(next http.Handler) http.Handler { return next }
+	if cfg.RateLimit.RequestsPerMinute > 0 {
+		limit = newRateLimiter(cfg.RateLimit, cfg.TrustProxy).middleware
+	}
+	// With auth on, keyed requests get their own limits and the IP limiter
+	// only applies to anonymous ones.
+	if cfg.Auth.Enabled {
+		if st == nil {
+			return nil, errors.New("server.auth requires a store for API keys")
+		}
+		limit = newAuthenticator(cfg.Auth, st).middleware(limit)
+	}
+	// Saved searches, bookmarks and history are shared by everyone who can
+	// reach the server, and saved searches drive the poller, webhooks and
+	// digests, so with auth on only key holders may change them, even when
+	// anonymous reads are allowed.
+	owner := func(next http.HandlerFunc) http.HandlerFunc { return next }
+	if cfg.Auth.Enabled {
+		owner = requireKey
+	}
+
+	// CORS wraps everything else, and routes behind it are mounted without a
+	// method so OPTIONS preflights reach it instead of the mux's 405.
+	cors, err := newCORS(cfg.CORS)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+
+	// "/" renders the UI, or falls back to JSON when only the API is enabled.
+	if cfg.Features.UI || cfg.Features.API {
+		mux.Handle("/", cors(limit(http.HandlerFunc(h.HandleHome))))
+	}
+	if cfg.Features.API {
+		api := http.NewServeMux()
+		api.HandleFunc("GET /api/search", h.HandleSearch)
+		api.HandleFunc("GET /api/v1/search", h.HandleSearchV1)
+		if st != nil {
+			api.HandleFunc("GET /api/v1/searches", h.HandleListSearches)
+			api.HandleFunc("POST /api/v1/searches", owner(h.HandleCreateSearch))
+			api.HandleFunc("GET /api/v1/searches/{name}", h.HandleGetSearch)
+			api.HandleFunc("DELETE /api/v1/searches/{name}", owner(h.HandleDeleteSearch))
+			api.HandleFunc("GET /api/v1/searches/{name}/new", h.HandleSearchMatches)
+			api.HandleFunc("GET /api/v1/bookmarks", h.HandleListBookmarks)
+			api.HandleFunc("POST /api/v1/bookmarks", owner(h.HandleAddBookmark))
+			api.HandleFunc("DELETE /api/v1/bookmarks/{id}", owner(h.HandleRemoveBookmark))
+			api.HandleFunc("GET /api/v1/history", h.HandleListHistory)
+			api.HandleFunc("POST /api/v1/history", owner(h.HandleRecordVisit))
+			api.HandleFunc("DELETE /api/v1/history", owner(h.HandleClearHistory))
+		}
+
+		mux.Handle("/api/", cors(limit(problemFallback(api))))
+	}
+	if cfg.Features.GraphQL {
+		gqlHandler, err := gql.NewHandler(engine)
+		if err != nil {
+			return nil, err
+		}
+		graphql := http.NewServeMux()
+		graphql.Handle("POST /graphql", limit(gqlHandler))
+		mux.Handle("/graphql", cors(graphql))
+	}
+	if cfg.Features.Feeds {
+		feeds := http.NewServeMux()
+		feeds.HandleFunc("GET /feed.xml", h.HandleFeedXML)
+		feeds.HandleFunc("GET /feed.json", h.HandleFeedJSON)
+		mux.Handle("/feed.xml", cors(limit(feeds)))
+		mux.Handle("/feed.json", cors(limit(feeds)))
+	}
+	if cfg.Features.Swagger {
+		mux.Handle("/swagger/", httpSwagger.WrapHandler)
+	}
+	if cfg.Features.Static {
+		staticFiles := http.FileServer(http.Dir(cfg.StaticDir))
+		mux.Handle("/static/", http.StripPrefix("/static", staticFiles))
+	}
+	if cfg.Features.Health {
+		mux.HandleFunc("/healthz", h.HandleHealth)
+		mux.HandleFunc("/readyz", h.HandleReady)
+	}
+
+	return mux, nil
+}
@@ -0,0 +1,465 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Numpkens/grip/internal/config"
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+func TestNewRouterFeatureSwitches(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+	cfg.Features.Swagger = false
+
+	router, err := NewRouter(cfg, logic.NewEngine(nil), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/healthz", http.StatusOK},
+		{"/readyz", http.StatusServiceUnavailable},
+		{"/api/search?q=go", http.StatusOK},
+		{"/", http.StatusOK},
+		{"/swagger/index.html", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+		if rr.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.path, tt.want, rr.Code)
+		}
+	}
+}
+
+func TestNewRouterMissingTemplate(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.TemplatePath = "does/not/exist.html"
+
+	if _, err := NewRouter(cfg, logic.NewEngine(nil), nil); err == nil {
+		t.Error("expected an error when the UI template cannot be parsed")
+	}
+}
+
+func TestAPIErrorsAreProblems(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+
+	router, err := NewRouter(cfg, logic.NewEngine(nil), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{"GET", "/api/v2/nothing", http.StatusNotFound},
+		{"POST", "/api/v1/search", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, nil))
+		if rr.Code != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, rr.Code)
+		}
+		if ct := rr.Header().Get("Content-Type"); ct != "application/problem+json" {
+			t.Errorf("%s %s: expected problem+json, got %s", tt.method, tt.path, ct)
+		}
+		if !strings.HasPrefix(rr.Body.String(), "{") {
+			t.Errorf("%s %s: body is not JSON: %q", tt.method, tt.path, rr.Body.String())
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+	cfg.RateLimit = config.RateLimit{RequestsPerMinute: 1, Burst: 2}
+
+	router, err := NewRouter(cfg, logic.NewEngine(nil), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var codes []int
+	for i := 0; i < 3; i++ {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/search", nil))
+		codes = append(codes, rr.Code)
+		if rr.Code == http.StatusTooManyRequests && rr.Header().Get("Retry-After") == "" {
+			t.Error("expected a Retry-After header")
+		}
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("expected 200, 200, 429, got %v", codes)
+	}
+
+	// "/" and the feeds run searches too, and draw on the same budget.
+	for _, path := range []string{"/", "/feed.xml", "/feed.json"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		if rr.Code != http.StatusTooManyRequests {
+			t.Errorf("%s: expected 429, got %d", path, rr.Code)
+		}
+	}
+}
+
+func TestGraphQLRoute(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+
+	router, err := NewRouter(cfg, logic.NewEngine(nil), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	body := strings.NewReader(`{"query": "{ search(query: \"go\") { query posts { title } } }"}`)
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/graphql", body))
+
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"query":"go"`) {
+		t.Errorf("unexpected GraphQL response %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestSavedSearchRoutes(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+	cfg.RateLimit.RequestsPerMinute = 0
+
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	router, err := NewRouter(cfg, logic.NewEngine(nil), st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"POST", "/api/v1/searches", `{"name": "golang", "query": "golang"}`, http.StatusCreated},
+		{"POST", "/api/v1/searches", `{"name": "golang", "query": "go"}`, http.StatusConflict},
+		{"POST", "/api/v1/searches", `{"name": "Bad Name", "query": "go"}`, http.StatusBadRequest},
+		{"POST", "/api/v1/searches", `{"name": "x", "query": "go", "sources": ["nope"]}`, http.StatusBadRequest},
+		{"POST", "/api/v1/searches", `not json`, http.StatusBadRequest},
+		{"GET", "/api/v1/searches", "", http.StatusOK},
+		{"GET", "/api/v1/searches/golang", "", http.StatusOK},
+		{"GET", "/api/v1/searches/golang/new", "", http.StatusOK},
+		{"GET", "/api/v1/searches/golang/new?since=yesterday", "", http.StatusBadRequest},
+		{"DELETE", "/api/v1/searches/golang", "", http.StatusNoContent},
+		{"GET", "/api/v1/searches/golang", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+		if rr.Code != tt.want {
+			t.Errorf("%s %s %s: expected %d, got %d: %s", tt.method, tt.path, tt.body, tt.want, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestBookmarkAndHistoryRoutes(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+	cfg.RateLimit.RequestsPerMinute = 0
+
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	router, err := NewRouter(cfg, logic.NewEngine(nil), st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	post := `{"title": "Go 1.26", "url": "https://go.dev/blog/go1.26", "source": "go.dev"}`
+	id := store.BookmarkID("https://go.dev/blog/go1.26")
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"POST", "/api/v1/bookmarks", post, http.StatusCreated},
+		{"POST", "/api/v1/bookmarks", post, http.StatusOK},
+		{"POST", "/api/v1/bookmarks", `{"title": "x", "url": "/relative"}`, http.StatusBadRequest},
+		{"POST", "/api/v1/bookmarks", `not json`, http.StatusBadRequest},
+		{"GET", "/api/v1/bookmarks", "", http.StatusOK},
+		{"DELETE", "/api/v1/bookmarks/" + id, "", http.StatusNoContent},
+		{"DELETE", "/api/v1/bookmarks/" + id, "", http.StatusNotFound},
+		{"POST", "/api/v1/history", post, http.StatusOK},
+		{"POST", "/api/v1/history", `{"url": ""}`, http.StatusBadRequest},
+		{"GET", "/api/v1/history?limit=10", "", http.StatusOK},
+		{"GET", "/api/v1/history?limit=0", "", http.StatusBadRequest},
+		{"DELETE", "/api/v1/history", "", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+		if rr.Code != tt.want {
+			t.Errorf("%s %s %s: expected %d, got %d: %s", tt.method, tt.path, tt.body, tt.want, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestChangesNeedKeyWithAuth(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, _, err := st.CreateKey("alice", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+	cfg.Auth = config.Auth{Enabled: true, AllowAnonymous: true}
+	router, err := NewRouter(cfg, logic.NewEngine(nil), st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	post := `{"title": "Go 1.26", "url": "https://go.dev/blog/go1.26", "source": "go.dev"}`
+	search := `{"name": "golang", "query": "golang"}`
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		key    string
+		want   int
+	}{
+		{"GET", "/api/v1/searches", "", "", http.StatusOK},
+		{"POST", "/api/v1/searches", search, "", http.StatusUnauthorized},
+		{"POST", "/api/v1/searches", search, secret, http.StatusCreated},
+		{"DELETE", "/api/v1/searches/golang", "", "", http.StatusUnauthorized},
+		{"GET", "/api/v1/searches/golang", "", "", http.StatusOK},
+		{"GET", "/api/v1/bookmarks", "", "", http.StatusOK},
+		{"POST", "/api/v1/bookmarks", post, "", http.StatusUnauthorized},
+		{"POST", "/api/v1/bookmarks", post, secret, http.StatusCreated},
+		{"DELETE", "/api/v1/bookmarks/" + store.BookmarkID("https://go.dev/blog/go1.26"), "", "", http.StatusUnauthorized},
+		{"GET", "/api/v1/history", "", "", http.StatusOK},
+		{"POST", "/api/v1/history", post, "", http.StatusUnauthorized},
+		{"POST", "/api/v1/history", post, secret, http.StatusOK},
+		{"DELETE", "/api/v1/history", "", "", http.StatusUnauthorized},
+		{"DELETE", "/api/v1/searches/golang", "", secret, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		if tt.key != "" {
+			req.Header.Set("X-API-Key", tt.key)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != tt.want {
+			t.Errorf("%s %s (key %t): expected %d, got %d: %s", tt.method, tt.path, tt.key != "", tt.want, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestUIPersonalNeedsAPI(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, api := range []bool{true, false} {
+		cfg := config.Default().Server
+		cfg.TemplatePath = filepath.Join("..", "..", "templates", "index.html")
+		cfg.Features.API = api
+		router, err := NewRouter(cfg, logic.NewEngine(nil), st)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept", "text/html")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if got := strings.Contains(rr.Body.String(), "/?view=saved"); got != api {
+			t.Errorf("api %t: expected bookmarks in the UI to be %t, got %t", api, api, got)
+		}
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, key, err := st.CreateKey("alice", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+	cfg.Auth = config.Auth{Enabled: true, KeyRateLimit: config.RateLimit{RequestsPerMinute: 1, Burst: 2}}
+
+	router, err := NewRouter(cfg, logic.NewEngine(nil), st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"no key", "", "", http.StatusUnauthorized},
+		{"wrong key", "X-API-Key", "grip_nope", http.StatusUnauthorized},
+		{"bearer", "Authorization", "Bearer " + secret, http.StatusOK},
+		{"header", "X-API-Key", secret, http.StatusOK},
+		{"over quota", "X-API-Key", secret, http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/v1/search", nil)
+		if tt.header != "" {
+			req.Header.Set(tt.header, tt.value)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, rr.Code, rr.Body.String())
+		}
+	}
+	for _, path := range []string{"/", "/feed.json"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("%s without a key: expected 401, got %d", path, rr.Code)
+		}
+	}
+
+	// Anonymous access falls back to the per-IP limiter.
+	cfg.Auth.AllowAnonymous = true
+	router, _ = NewRouter(cfg, logic.NewEngine(nil), st)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/search", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("anonymous: expected 200, got %d", rr.Code)
+	}
+
+	if err := st.RevokeKey(key.ID); err != nil {
+		t.Fatal(err)
+	}
+	a := newAuthenticator(cfg.Auth, st)
+	if _, ok := a.lookup(secret, time.Now()); ok {
+		t.Error("expected a revoked key to be rejected")
+	}
+}
+
+func TestAuthenticatorFlushesUsage(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, _, _ := st.CreateKey("bob", 0)
+
+	a := newAuthenticator(config.Auth{Enabled: true}, st)
+	now := time.Now()
+	key, ok := a.lookup(secret, now)
+	if !ok {
+		t.Fatal("expected the key to be accepted")
+	}
+	a.record(key.ID, now)
+	a.record(key.ID, now)
+
+	// The next lookup after keyRefresh writes the counters back.
+	a.lookup(secret, now.Add(keyRefresh+time.Second))
+
+	keys, _ := st.Keys()
+	if len(keys) != 1 || keys[0].Requests != 2 || keys[0].LastUsedAt.IsZero() {
+		t.Errorf("expected 2 requests recorded, got %+v", keys)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default().Server
+	cfg.Features.UI = false
+	cfg.Auth = config.Auth{Enabled: true}
+	cfg.CORS = config.CORS{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Authorization"},
+		AllowCredentials: true,
+		MaxAge:           config.Duration(time.Hour),
+	}
+
+	router, err := NewRouter(cfg, logic.NewEngine(nil), st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Preflights are answered before auth, on every cross-origin route.
+	for _, path := range []string{"/api/v1/search", "/graphql", "/feed.json"} {
+		req := httptest.NewRequest("OPTIONS", path, nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		h := rr.Header()
+		if rr.Code != http.StatusNoContent ||
+			h.Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+			h.Get("Access-Control-Allow-Methods") != "GET, POST" ||
+			h.Get("Access-Control-Allow-Headers") != "Authorization" ||
+			h.Get("Access-Control-Allow-Credentials") != "true" ||
+			h.Get("Access-Control-Max-Age") != "3600" {
+			t.Errorf("%s: unexpected preflight %d %v", path, rr.Code, h)
+		}
+	}
+
+	// Other origins get no CORS headers, so the browser blocks the response.
+	req := httptest.NewRequest("GET", "/api/v1/search", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Header().Get("Access-Control-Allow-Origin") != "" || rr.Header().Get("Vary") != "Origin" {
+		t.Errorf("unexpected headers for a foreign origin: %v", rr.Header())
+	}
+
+	// Handlers add their own Vary values without dropping Origin.
+	cfg.Auth.Enabled = false
+	router, _ = NewRouter(cfg, logic.NewEngine(nil), st)
+	req = httptest.NewRequest("GET", "/api/v1/search", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if vary := rr.Header().Values("Vary"); rr.Code != http.StatusOK || len(vary) != 2 || rr.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("unexpected response %d: %v", rr.Code, rr.Header())
+	}
+
+	cfg.CORS.AllowedOrigins = []string{"*"}
+	if _, err := NewRouter(cfg, logic.NewEngine(nil), st); err == nil {
+		t.Error("expected credentials with a wildcard origin to be rejected")
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRunDrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("done"))
+	})
+
+	// Port 0 picks a free port, so parallel test runs cannot collide.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := New(ln.Addr().String(), mux)
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- Serve(ctx, srv, ln) }()
+
+	var resp *http.Response
+	reqErr := make(chan error, 1)
+	go func() {
+		var err error
+		resp, err = http.Get("http://" + ln.Addr().String() + "/")
+		reqErr <- err
+	}()
+
+	<-started
+	cancel()
+
+	if err := <-reqErr; err != nil {
+		t.Fatalf("in-flight request was cut off: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	if err := <-runErr; err != nil {
+		t.Errorf("expected clean shutdown, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// maxBookmarks caps the bookmarks, since every change rewrites the whole file.
+const maxBookmarks = 1000
+
+// Bookmark is a post saved to read later.
+type Bookmark struct {
+	// ID is derived from the post URL, so saving the same post twice is a no-op.
+	ID      string     `json:"id" example:"3f1a9c0e5b7d2468"`
+	Post    logic.Post `json:"post"`
+	SavedAt time.Time  `json:"saved_at" example:"2026-01-21T10:00:00Z"`
+}
+
+// BookmarkID returns the ID a bookmark of url has.
+func BookmarkID(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:8])
+}
+
+// AddBookmark saves p and reports whether it was new. Saving a post that is
+// already bookmarked returns the existing bookmark unchanged, and saving a new
+// one once maxBookmarks are saved fails with ErrFull.
+func (s *Store) AddBookmark(p logic.Post) (Bookmark, bool, error) {
+	if p.URL == "" {
+		return Bookmark{}, false, fmt.Errorf("a bookmark needs a post URL")
+	}
+
+	b := Bookmark{ID: BookmarkID(p.URL), Post: p, SavedAt: time.Now().UTC()}
+	created := false
+	err := s.update(func(st *state) error {
+		if existing, ok := st.Bookmarks[b.ID]; ok {
+			b = *existing
+			return nil
+		}
+		if len(st.Bookmarks) >= maxBookmarks {
+			return fmt.Errorf("at most %d posts can be bookmarked: %w", maxBookmarks, ErrFull)
+		}
+		if st.Bookmarks == nil {
+			st.Bookmarks = make(map[string]*Bookmark)
+		}
+		st.Bookmarks[b.ID] = &b
+		created = true
+		return nil
+	})
+	return b, created, err
+}
+
+// RemoveBookmark deletes the bookmark with the given ID.
+func (s *Store) RemoveBookmark(id string) error {
+	return s.update(func(st *state) error {
+		if _, ok := st.Bookmarks[id]; !ok {
+			return fmt.Errorf("bookmark %s: %w", id, ErrNotFound)
+		}
+		delete(st.Bookmarks, id)
+		return nil
+	})
+}
+
+// Bookmarks returns every bookmark, most recently saved first.
+func (s *Store) Bookmarks() ([]Bookmark, error) {
+	var out []Bookmark
+	err := s.view(func(st *state) {
+		for _, b := range st.Bookmarks {
+			out = append(out, *b)
+		}
+	})
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].SavedAt.Equal(out[j].SavedAt) {
+			return out[i].SavedAt.After(out[j].SavedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, err
+}
+
+// BookmarkedURLs returns the set of bookmarked post URLs, for annotating results.
+func (s *Store) BookmarkedURLs() (map[string]bool, error) {
+	urls := make(map[string]bool)
+	err := s.view(func(st *state) {
+		for _, b := range st.Bookmarks {
+			urls[b.Post.URL] = true
+		}
+	})
+	return urls, err
+}
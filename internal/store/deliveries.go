@@ -0,0 +1,46 @@
+package store
+
+import "time"
+
+// maxDeliveries caps the webhook delivery log.
+const maxDeliveries = 500
+
+// Delivery records one webhook notification, including every retry.
+type Delivery struct {
+	ID      string    `json:"id"`
+	Webhook string    `json:"webhook"`
+	Search  string    `json:"search"`
+	Posts   int       `json:"posts"`
+	SentAt  time.Time `json:"sent_at"`
+	// Attempts counts requests made, so a first-try success is 1.
+	Attempts int `json:"attempts"`
+	// StatusCode is the last HTTP status received, or zero if none arrived.
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	OK         bool   `json:"ok"`
+}
+
+// AddDelivery appends d to the delivery log, dropping the oldest entries past the cap.
+func (s *Store) AddDelivery(d Delivery) error {
+	return s.update(func(st *state) error {
+		st.Deliveries = append(st.Deliveries, d)
+		if n := len(st.Deliveries); n > maxDeliveries {
+			st.Deliveries = append([]Delivery(nil), st.Deliveries[n-maxDeliveries:]...)
+		}
+		return nil
+	})
+}
+
+// Deliveries returns up to limit log entries, newest first. A limit of zero returns them all.
+func (s *Store) Deliveries(limit int) ([]Delivery, error) {
+	var out []Delivery
+	err := s.view(func(st *state) {
+		for i := len(st.Deliveries) - 1; i >= 0; i-- {
+			if limit > 0 && len(out) == limit {
+				break
+			}
+			out = append(out, st.Deliveries[i])
+		}
+	})
+	return out, err
+}
@@ -0,0 +1,23 @@
+package store
+
+import "time"
+
+// LastDigest returns when a digest was last sent to email, or the zero time.
+func (s *Store) LastDigest(email string) (time.Time, error) {
+	var t time.Time
+	err := s.view(func(st *state) {
+		t = st.Digests[email]
+	})
+	return t, err
+}
+
+// MarkDigest records that a digest covering matches up to t was sent to email.
+func (s *Store) MarkDigest(email string, t time.Time) error {
+	return s.update(func(st *state) error {
+		if st.Digests == nil {
+			st.Digests = make(map[string]time.Time)
+		}
+		st.Digests[email] = t
+		return nil
+	})
+}
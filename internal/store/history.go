@@ -0,0 +1,94 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+// maxHistory caps the reading history; the least recently opened posts go first.
+const maxHistory = 1000
+
+// Visit records that a post was opened.
+type Visit struct {
+	Post     logic.Post `json:"post"`
+	OpenedAt time.Time  `json:"opened_at" example:"2026-01-21T10:00:00Z"`
+	// Opens counts every time the post was opened, so re-reads are visible.
+	Opens int `json:"opens" example:"1"`
+}
+
+// RecordVisit marks p as read at t. Opening a post again moves it to the top of
+// the history and keeps the post details from the first visit.
+func (s *Store) RecordVisit(p logic.Post, t time.Time) (Visit, error) {
+	if p.URL == "" {
+		return Visit{}, fmt.Errorf("a visit needs a post URL")
+	}
+
+	var v Visit
+	err := s.update(func(st *state) error {
+		if st.History == nil {
+			st.History = make(map[string]*Visit)
+		}
+		existing, ok := st.History[p.URL]
+		if !ok {
+			existing = &Visit{Post: p}
+			st.History[p.URL] = existing
+		}
+		existing.OpenedAt = t.UTC()
+		existing.Opens++
+		v = *existing
+
+		for len(st.History) > maxHistory {
+			var oldest string
+			for url, h := range st.History {
+				if oldest == "" || h.OpenedAt.Before(st.History[oldest].OpenedAt) {
+					oldest = url
+				}
+			}
+			delete(st.History, oldest)
+		}
+		return nil
+	})
+	return v, err
+}
+
+// History returns up to limit visits, most recently opened first. A limit of zero returns them all.
+func (s *Store) History(limit int) ([]Visit, error) {
+	var out []Visit
+	err := s.view(func(st *state) {
+		for _, v := range st.History {
+			out = append(out, *v)
+		}
+	})
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].OpenedAt.Equal(out[j].OpenedAt) {
+			return out[i].OpenedAt.After(out[j].OpenedAt)
+		}
+		return out[i].Post.URL < out[j].Post.URL
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, err
+}
+
+// ReadURLs returns the set of opened post URLs, for annotating results.
+func (s *Store) ReadURLs() (map[string]bool, error) {
+	urls := make(map[string]bool)
+	err := s.view(func(st *state) {
+		for url := range st.History {
+			urls[url] = true
+		}
+	})
+	return urls, err
+}
+
+// ClearHistory forgets every visit.
+func (s *Store) ClearHistory() error {
+	return s.update(func(st *state) error {
+		st.History = nil
+		return nil
+	})
+}
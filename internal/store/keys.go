@@ -0,0 +1,137 @@
+package store
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// keyPrefix starts every API key so leaked keys are easy to grep for.
+const keyPrefix = "grip_"
+
+// APIKey is an API key belonging to an account. Only a hash of the secret is kept.
+type APIKey struct {
+	// ID is the public part of the key, shown in listings and used to revoke it.
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+	Hash  string `json:"hash"`
+	// RequestsPerMinute overrides the server's per-key limit when non-zero.
+	RequestsPerMinute int       `json:"requests_per_minute,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	RevokedAt         time.Time `json:"revoked_at,omitzero"`
+	Requests          int64     `json:"requests"`
+	LastUsedAt        time.Time `json:"last_used_at,omitzero"`
+}
+
+// Revoked reports whether the key has been revoked.
+func (k APIKey) Revoked() bool { return !k.RevokedAt.IsZero() }
+
+// KeyUsage is a batch of requests made with one key.
+type KeyUsage struct {
+	Requests   int64
+	LastUsedAt time.Time
+}
+
+// HashKey returns the stored form of a key. Keys carry 256 bits of randomness,
+// so a plain SHA-256 is enough; there is nothing to brute-force.
+func HashKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateKey issues a key for owner and returns the secret, which is shown once
+// and cannot be recovered later.
+func (s *Store) CreateKey(owner string, requestsPerMinute int) (string, APIKey, error) {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return "", APIKey{}, fmt.Errorf("key owner is required")
+	}
+	if requestsPerMinute < 0 {
+		return "", APIKey{}, fmt.Errorf("requests per minute must not be negative")
+	}
+
+	idBytes := make([]byte, 4)
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", APIKey{}, fmt.Errorf("generating key: %w", err)
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", APIKey{}, fmt.Errorf("generating key: %w", err)
+	}
+
+	id := hex.EncodeToString(idBytes)
+	secret := keyPrefix + id + "_" + hex.EncodeToString(secretBytes)
+	key := APIKey{
+		ID:                id,
+		Owner:             owner,
+		Hash:              HashKey(secret),
+		RequestsPerMinute: requestsPerMinute,
+		CreatedAt:         time.Now().UTC(),
+	}
+
+	err := s.update(func(st *state) error {
+		if st.Keys == nil {
+			st.Keys = make(map[string]*APIKey)
+		}
+		if _, ok := st.Keys[id]; ok {
+			return fmt.Errorf("key %s: %w", id, ErrExists)
+		}
+		st.Keys[id] = &key
+		return nil
+	})
+	if err != nil {
+		return "", APIKey{}, err
+	}
+	return secret, key, nil
+}
+
+// RevokeKey disables the key with the given ID. Revoked keys are kept so
+// their usage stays on record.
+func (s *Store) RevokeKey(id string) error {
+	return s.update(func(st *state) error {
+		k, ok := st.Keys[id]
+		if !ok {
+			return fmt.Errorf("key %s: %w", id, ErrNotFound)
+		}
+		if !k.Revoked() {
+			k.RevokedAt = time.Now().UTC()
+		}
+		return nil
+	})
+}
+
+// Keys returns every key, oldest first.
+func (s *Store) Keys() ([]APIKey, error) {
+	var out []APIKey
+	err := s.view(func(st *state) {
+		for _, k := range st.Keys {
+			out = append(out, *k)
+		}
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, err
+}
+
+// AddKeyUsage adds batched request counts, keyed by key ID. Unknown IDs are ignored.
+func (s *Store) AddKeyUsage(usage map[string]KeyUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+	return s.update(func(st *state) error {
+		for id, u := range usage {
+			k, ok := st.Keys[id]
+			if !ok {
+				continue
+			}
+			k.Requests += u.Requests
+			if u.LastUsedAt.After(k.LastUsedAt) {
+				k.LastUsedAt = u.LastUsedAt
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,9 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package store
+
+import "os"
+
+// lockFile is a no-op where there are no advisory locks; only the Store's
+// mutex guards writes there.
+func lockFile(f *os.File) error { return nil }
@@ -0,0 +1,171 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+const (
+	// seenRetention is how long a URL is remembered after it last showed up in
+	// a poll. Anything older has long dropped out of the newest results.
+	seenRetention = 30 * 24 * time.Hour
+	// maxMatches caps the match history kept per saved search.
+	maxMatches = 200
+)
+
+// SavedSearch is a named query that the scheduler polls in the background.
+type SavedSearch struct {
+	Name  string `json:"name" example:"golang"`
+	Query string `json:"query" example:"golang"`
+	// Sources restricts the search like SearchOptions.Sources; empty means all.
+	Sources   []string  `json:"sources,omitempty" example:"Dev.to,Lobsters"`
+	CreatedAt time.Time `json:"created_at" example:"2026-01-21T10:00:00Z"`
+	// LastPolledAt is zero until the first poll has recorded a baseline.
+	LastPolledAt time.Time `json:"last_polled_at,omitzero" example:"2026-01-21T10:15:00Z"`
+}
+
+// Match is a post a saved search found for the first time.
+type Match struct {
+	Search  string     `json:"search" example:"golang"`
+	Post    logic.Post `json:"post"`
+	FoundAt time.Time  `json:"found_at" example:"2026-01-21T10:15:00Z"`
+}
+
+// watchState is the polling history of one saved search.
+type watchState struct {
+	// Seen maps each post URL to the last poll it appeared in.
+	Seen    map[string]time.Time `json:"seen"`
+	Matches []Match              `json:"matches,omitempty"`
+}
+
+// Searches returns every saved search ordered by name.
+func (s *Store) Searches() ([]SavedSearch, error) {
+	var out []SavedSearch
+	err := s.view(func(st *state) {
+		for _, ss := range st.Searches {
+			out = append(out, *ss)
+		}
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, err
+}
+
+// Search returns the saved search called name, or ErrNotFound.
+func (s *Store) Search(name string) (SavedSearch, error) {
+	var out SavedSearch
+	var found bool
+	err := s.view(func(st *state) {
+		if ss, ok := st.Searches[name]; ok {
+			out, found = *ss, true
+		}
+	})
+	if err == nil && !found {
+		err = fmt.Errorf("saved search %q: %w", name, ErrNotFound)
+	}
+	return out, err
+}
+
+// AddSearch stores ss, stamping CreatedAt when it is unset. It fails with
+// ErrInvalidName or ErrExists rather than overwriting.
+func (s *Store) AddSearch(ss SavedSearch) (SavedSearch, error) {
+	if !validName(ss.Name) {
+		return ss, ErrInvalidName
+	}
+	if ss.CreatedAt.IsZero() {
+		ss.CreatedAt = time.Now().UTC()
+	}
+	ss.LastPolledAt = time.Time{}
+
+	err := s.update(func(st *state) error {
+		if _, ok := st.Searches[ss.Name]; ok {
+			return fmt.Errorf("saved search %q: %w", ss.Name, ErrExists)
+		}
+		if st.Searches == nil {
+			st.Searches = make(map[string]*SavedSearch)
+		}
+		st.Searches[ss.Name] = &ss
+		return nil
+	})
+	return ss, err
+}
+
+// DeleteSearch removes the saved search called name along with its history.
+func (s *Store) DeleteSearch(name string) error {
+	return s.update(func(st *state) error {
+		if _, ok := st.Searches[name]; !ok {
+			return fmt.Errorf("saved search %q: %w", name, ErrNotFound)
+		}
+		delete(st.Searches, name)
+		delete(st.Watches, name)
+		return nil
+	})
+}
+
+// RecordPoll marks posts as seen by the saved search called name and returns
+// the ones it had not seen before, newest first. The first poll of a search
+// only records a baseline and returns nothing, so creating a search does not
+// flag its whole first page as new.
+func (s *Store) RecordPoll(name string, posts []logic.Post, now time.Time) ([]logic.Post, error) {
+	var fresh []logic.Post
+	err := s.update(func(st *state) error {
+		ss, ok := st.Searches[name]
+		if !ok {
+			return fmt.Errorf("saved search %q: %w", name, ErrNotFound)
+		}
+		if st.Watches == nil {
+			st.Watches = make(map[string]*watchState)
+		}
+		w, ok := st.Watches[name]
+		if !ok {
+			w = &watchState{Seen: make(map[string]time.Time)}
+			st.Watches[name] = w
+		}
+
+		baseline := ss.LastPolledAt.IsZero()
+		for _, p := range posts {
+			if _, seen := w.Seen[p.URL]; !seen && !baseline {
+				fresh = append(fresh, p)
+				w.Matches = append(w.Matches, Match{Search: name, Post: p, FoundAt: now})
+			}
+			w.Seen[p.URL] = now
+		}
+
+		for url, last := range w.Seen {
+			if now.Sub(last) > seenRetention {
+				delete(w.Seen, url)
+			}
+		}
+		if n := len(w.Matches); n > maxMatches {
+			w.Matches = append([]Match(nil), w.Matches[n-maxMatches:]...)
+		}
+		ss.LastPolledAt = now
+		return nil
+	})
+	return fresh, err
+}
+
+// Matches returns the posts the saved search called name has flagged as new
+// since the given time, newest first. A zero since returns the whole history.
+func (s *Store) Matches(name string, since time.Time) ([]Match, error) {
+	var out []Match
+	var found bool
+	err := s.view(func(st *state) {
+		if _, found = st.Searches[name]; !found {
+			return
+		}
+		if w, ok := st.Watches[name]; ok {
+			for i := len(w.Matches) - 1; i >= 0; i-- {
+				if m := w.Matches[i]; m.FoundAt.After(since) {
+					out = append(out, m)
+				}
+			}
+		}
+	})
+	if err == nil && !found {
+		err = fmt.Errorf("saved search %q: %w", name, ErrNotFound)
+	}
+	return out, err
+}
@@ -0,0 +1,182 @@
+// Package store keeps GRIP's server-side state in a single JSON file. Writes
+// hold a lock file across read-modify-write and replace the file atomically,
+// so the server and the CLI can share one store without a database. Reads are
+// served from memory until the file changes.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Errors returned for lookups and inserts, so callers can map them onto API codes.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrExists      = errors.New("already exists")
+	ErrFull        = errors.New("limit reached")
+	ErrInvalidName = errors.New("names must be 1-64 lowercase letters, digits, '-' or '_'")
+)
+
+// Store is a JSON file guarded by a mutex within the process and by a lock
+// file across processes.
+type Store struct {
+	path string
+	mu   sync.Mutex
+
+	// cached is the last state read or written, valid while the file still
+	// matches cachedInfo. Views share it, so they must not modify it.
+	cached     *state
+	cachedInfo os.FileInfo
+}
+
+// state is the file layout. New collections are added as fields so older
+// files keep loading.
+type state struct {
+	Searches   map[string]*SavedSearch `json:"searches,omitempty"`
+	Watches    map[string]*watchState  `json:"watches,omitempty"`
+	Deliveries []Delivery              `json:"deliveries,omitempty"`
+	// Digests maps each recipient to the end of the last digest they were sent.
+	Digests   map[string]time.Time `json:"digests,omitempty"`
+	Keys      map[string]*APIKey   `json:"keys,omitempty"`
+	Bookmarks map[string]*Bookmark `json:"bookmarks,omitempty"`
+	// History is keyed by post URL.
+	History map[string]*Visit `json:"history,omitempty"`
+}
+
+// Open returns a store backed by path, creating its directory if needed. The
+// file itself is only created on the first write.
+func Open(path string) (*Store, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	s := &Store{path: path}
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Path reports the file backing the store.
+func (s *Store) Path() string { return s.path }
+
+// view runs fn on the current contents of the file, which it must not modify.
+func (s *Store) view(fn func(*state)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fn(&state{})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if s.cached == nil || !sameVersion(info, s.cachedInfo) {
+		st, err := s.load()
+		if err != nil {
+			return err
+		}
+		s.cached, s.cachedInfo = st, info
+	}
+	fn(s.cached)
+	return nil
+}
+
+// update runs fn on a fresh copy of the file and saves the result unless fn
+// fails. The lock file is held throughout so other processes cannot write in
+// between.
+func (s *Store) update(fn func(*state) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	if err := lockFile(lock); err != nil {
+		return fmt.Errorf("store %s: lock: %w", s.path, err)
+	}
+
+	st, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := fn(st); err != nil {
+		return err
+	}
+	if err := s.save(st); err != nil {
+		return err
+	}
+	if info, err := os.Stat(s.path); err == nil {
+		s.cached, s.cachedInfo = st, info
+	}
+	return nil
+}
+
+// sameVersion reports whether a and b describe the same revision of the file.
+// Every save renames a new file into place, so a write by any process changes
+// its identity even when size and modification time happen to match.
+func sameVersion(a, b os.FileInfo) bool {
+	return os.SameFile(a, b) && a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}
+
+func (s *Store) load() (*state, error) {
+	st := &state{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("store %s: %w", s.path, err)
+	}
+	return st, nil
+}
+
+// save replaces the file atomically so a crash never leaves it half-written.
+func (s *Store) save(st *state) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".grip-store-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// validName reports whether name is a short slug safe to use in URLs.
+func validName(name string) bool {
+	if len(name) == 0 || len(name) > 64 {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
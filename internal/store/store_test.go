@@ -0,0 +1,275 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+)
+
+func openTemp(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "data", "grip-data.json"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	return s
+}
+
+func TestSavedSearchCRUD(t *testing.T) {
+	s := openTemp(t)
+
+	if _, err := s.AddSearch(SavedSearch{Name: "Go Lang", Query: "golang"}); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, got %v", err)
+	}
+	if _, err := s.AddSearch(SavedSearch{Name: "htmx", Query: "htmx"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := s.AddSearch(SavedSearch{Name: "golang", Query: "golang", Sources: []string{"devto"}}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := s.AddSearch(SavedSearch{Name: "htmx", Query: "other"}); !errors.Is(err, ErrExists) {
+		t.Errorf("expected ErrExists, got %v", err)
+	}
+
+	// A second handle on the same file sees the writes of the first.
+	other, err := Open(s.Path())
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	list, err := other.Searches()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "golang" || list[1].Name != "htmx" {
+		t.Fatalf("expected [golang htmx], got %+v", list)
+	}
+	if list[0].CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be stamped")
+	}
+
+	if err := other.DeleteSearch("htmx"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := s.Search("htmx"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := s.DeleteSearch("htmx"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestRecordPollFlagsOnlyNewPosts(t *testing.T) {
+	s := openTemp(t)
+	if _, err := s.AddSearch(SavedSearch{Name: "golang", Query: "golang"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := []logic.Post{{Title: "A", URL: "https://a"}, {Title: "B", URL: "https://b"}}
+
+	fresh, err := s.RecordPoll("golang", first, t0)
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(fresh) != 0 {
+		t.Errorf("expected the first poll to be a silent baseline, got %d new", len(fresh))
+	}
+
+	second := append([]logic.Post{{Title: "C", URL: "https://c"}}, first...)
+	fresh, err = s.RecordPoll("golang", second, t0.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if len(fresh) != 1 || fresh[0].URL != "https://c" {
+		t.Errorf("expected only https://c to be new, got %+v", fresh)
+	}
+
+	fresh, _ = s.RecordPoll("golang", second, t0.Add(2*time.Hour))
+	if len(fresh) != 0 {
+		t.Errorf("expected nothing new on an identical poll, got %+v", fresh)
+	}
+
+	matches, err := s.Matches("golang", time.Time{})
+	if err != nil {
+		t.Fatalf("matches: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Post.URL != "https://c" || !matches[0].FoundAt.Equal(t0.Add(time.Hour)) {
+		t.Errorf("unexpected match history: %+v", matches)
+	}
+	if matches, _ := s.Matches("golang", t0.Add(time.Hour)); len(matches) != 0 {
+		t.Errorf("expected since to exclude older matches, got %+v", matches)
+	}
+
+	ss, _ := s.Search("golang")
+	if !ss.LastPolledAt.Equal(t0.Add(2 * time.Hour)) {
+		t.Errorf("expected LastPolledAt to advance, got %s", ss.LastPolledAt)
+	}
+
+	if _, err := s.RecordPoll("missing", first, t0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	s := openTemp(t)
+
+	if _, _, err := s.CreateKey(" ", 0); err == nil {
+		t.Error("expected an owner to be required")
+	}
+	secret, key, err := s.CreateKey("alice", 30)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !strings.HasPrefix(secret, "grip_"+key.ID+"_") || key.Hash != HashKey(secret) || strings.Contains(key.Hash, secret) {
+		t.Errorf("unexpected key %+v for secret %q", key, secret)
+	}
+
+	now := time.Now().UTC()
+	if err := s.AddKeyUsage(map[string]KeyUsage{key.ID: {Requests: 3, LastUsedAt: now}, "unknown": {Requests: 1}}); err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if err := s.RevokeKey(key.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := s.RevokeKey("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	keys, err := s.Keys()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Requests != 3 || !keys[0].Revoked() || keys[0].RequestsPerMinute != 30 {
+		t.Errorf("unexpected keys: %+v", keys)
+	}
+}
+
+func TestBookmarks(t *testing.T) {
+	s := openTemp(t)
+
+	p := logic.Post{Title: "Go 1.26", URL: "https://go.dev/blog/go1.26", Source: "go.dev"}
+	b, created, err := s.AddBookmark(p)
+	if err != nil || !created || b.ID != BookmarkID(p.URL) {
+		t.Fatalf("add: %+v %v %v", b, created, err)
+	}
+	p.Title = "renamed"
+	again, created, err := s.AddBookmark(p)
+	if err != nil || created || again.Post.Title != "Go 1.26" || !again.SavedAt.Equal(b.SavedAt) {
+		t.Errorf("expected re-adding to return the original bookmark, got %+v %v %v", again, created, err)
+	}
+
+	urls, err := s.BookmarkedURLs()
+	if err != nil || !urls[p.URL] || len(urls) != 1 {
+		t.Errorf("unexpected bookmarked URLs %v: %v", urls, err)
+	}
+
+	if err := s.RemoveBookmark(b.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := s.RemoveBookmark(b.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if list, _ := s.Bookmarks(); len(list) != 0 {
+		t.Errorf("expected no bookmarks, got %+v", list)
+	}
+
+	err = s.update(func(st *state) error {
+		st.Bookmarks = make(map[string]*Bookmark)
+		for i := range maxBookmarks {
+			url := fmt.Sprintf("https://example.com/%d", i)
+			st.Bookmarks[BookmarkID(url)] = &Bookmark{ID: BookmarkID(url), Post: logic.Post{URL: url}}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.AddBookmark(p); !errors.Is(err, ErrFull) {
+		t.Errorf("expected ErrFull once the limit is reached, got %v", err)
+	}
+	if _, created, err := s.AddBookmark(logic.Post{URL: "https://example.com/0"}); err != nil || created {
+		t.Errorf("expected a saved post to stay saved at the limit, got %v %v", created, err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	s := openTemp(t)
+
+	first := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	a := logic.Post{Title: "A", URL: "https://example.com/a"}
+	b := logic.Post{Title: "B", URL: "https://example.com/b"}
+	if _, err := s.RecordVisit(a, first); err != nil {
+		t.Fatalf("visit: %v", err)
+	}
+	if _, err := s.RecordVisit(b, first.Add(time.Minute)); err != nil {
+		t.Fatalf("visit: %v", err)
+	}
+	v, err := s.RecordVisit(a, first.Add(time.Hour))
+	if err != nil || v.Opens != 2 {
+		t.Fatalf("expected a second open, got %+v %v", v, err)
+	}
+
+	visits, err := s.History(0)
+	if err != nil || len(visits) != 2 || visits[0].Post.URL != a.URL {
+		t.Errorf("expected the re-opened post first, got %+v %v", visits, err)
+	}
+	if visits, _ := s.History(1); len(visits) != 1 {
+		t.Errorf("expected the limit to apply, got %+v", visits)
+	}
+	if urls, _ := s.ReadURLs(); !urls[a.URL] || !urls[b.URL] {
+		t.Errorf("unexpected read URLs %v", urls)
+	}
+
+	if err := s.ClearHistory(); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if urls, _ := s.ReadURLs(); len(urls) != 0 {
+		t.Errorf("expected no read URLs after clearing, got %v", urls)
+	}
+}
+
+// TestSharedFile opens the file twice, as the server and the CLI do, so only
+// the lock file keeps their writes from overwriting each other.
+func TestSharedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grip-data.json")
+	server, err := Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	cli, err := Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// Prime the server's cache so the CLI's writes have to invalidate it.
+	if urls, err := server.ReadURLs(); err != nil || len(urls) != 0 {
+		t.Fatalf("expected an empty store, got %v %v", urls, err)
+	}
+
+	var wg sync.WaitGroup
+	for i, s := range []*Store{server, cli} {
+		for j := range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				post := logic.Post{URL: fmt.Sprintf("https://example.com/%d/%d", i, j)}
+				if _, err := s.RecordVisit(post, time.Now()); err != nil {
+					t.Errorf("visit: %v", err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	for _, s := range []*Store{server, cli} {
+		if urls, err := s.ReadURLs(); err != nil || len(urls) != 100 {
+			t.Errorf("expected every visit to survive, got %d %v", len(urls), err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Package watch polls saved searches in the background and records which
+// posts each one is seeing for the first time.
+package watch
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+// ErrAllFailed is returned by Poll when no source answered, in which case
+// nothing is recorded so the next successful poll is compared to the last good one.
+var ErrAllFailed = errors.New("every source failed or timed out")
+
+// Scheduler runs every saved search in Store against Engine on a fixed interval.
+type Scheduler struct {
+	Engine   *logic.Engine
+	Store    *store.Store
+	Interval time.Duration
+	// OnNew, when set, is called after a poll finds posts a search had not seen.
+	OnNew func(ctx context.Context, ss store.SavedSearch, posts []logic.Post)
+}
+
+// Run polls immediately and then every Interval until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.PollAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PollAll polls each saved search in turn. Failures are logged and skipped so
+// one broken search does not hold up the rest.
+func (s *Scheduler) PollAll(ctx context.Context) {
+	searches, err := s.Store.Searches()
+	if err != nil {
+		log.Printf("watch: listing saved searches: %v", err)
+		return
+	}
+	for _, ss := range searches {
+		if ctx.Err() != nil {
+			return
+		}
+		fresh, err := s.Poll(ctx, ss)
+		if err != nil {
+			log.Printf("watch: %s: %v", ss.Name, err)
+			continue
+		}
+		if len(fresh) > 0 {
+			log.Printf("watch: %s: %d new post(s)", ss.Name, len(fresh))
+			if s.OnNew != nil {
+				s.OnNew(ctx, ss, fresh)
+			}
+		}
+	}
+}
+
+// Poll runs one saved search and returns the posts it had not seen before.
+func (s *Scheduler) Poll(ctx context.Context, ss store.SavedSearch) ([]logic.Post, error) {
+	res, err := s.Engine.Search(ctx, logic.SearchOptions{
+		Query:   ss.Query,
+		Limit:   logic.MaxLimit,
+		Sources: ss.Sources,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.AllFailed() {
+		return nil, ErrAllFailed
+	}
+	return s.Store.RecordPoll(ss.Name, res.Posts, time.Now().UTC())
+}
@@ -0,0 +1,73 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Numpkens/grip/internal/logic"
+	"github.com/Numpkens/grip/internal/store"
+)
+
+type fakeSource struct {
+	posts []logic.Post
+	err   error
+}
+
+func (f *fakeSource) Search(ctx context.Context, query string) ([]logic.Post, error) {
+	return f.posts, f.err
+}
+
+func TestPollAllReportsNewPosts(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.AddSearch(store.SavedSearch{Name: "golang", Query: "golang"}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	src := &fakeSource{posts: []logic.Post{{Title: "A", URL: "https://a", PublishedAt: now}}}
+
+	var got []logic.Post
+	s := &Scheduler{
+		Engine: logic.NewEngine([]logic.Source{src}),
+		Store:  st,
+		OnNew: func(ctx context.Context, ss store.SavedSearch, posts []logic.Post) {
+			got = append(got, posts...)
+		},
+	}
+
+	s.PollAll(context.Background())
+	if len(got) != 0 {
+		t.Fatalf("expected the baseline poll to report nothing, got %+v", got)
+	}
+
+	src.posts = append(src.posts, logic.Post{Title: "B", URL: "https://b", PublishedAt: now.Add(time.Minute)})
+	s.PollAll(context.Background())
+	if len(got) != 1 || got[0].URL != "https://b" {
+		t.Fatalf("expected https://b to be reported, got %+v", got)
+	}
+}
+
+func TestPollSkipsFailedRuns(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "grip-data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, _ := st.AddSearch(store.SavedSearch{Name: "golang", Query: "golang"})
+
+	s := &Scheduler{
+		Engine: logic.NewEngine([]logic.Source{&fakeSource{err: errors.New("boom")}}),
+		Store:  st,
+	}
+	if _, err := s.Poll(context.Background(), ss); !errors.Is(err, ErrAllFailed) {
+		t.Fatalf("expected ErrAllFailed, got %v", err)
+	}
+	if ss, _ := st.Search("golang"); !ss.LastPolledAt.IsZero() {
+		t.Error("expected a failed poll not to record a baseline")
+	}
+}